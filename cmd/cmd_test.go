@@ -2,19 +2,27 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
@@ -40,9 +48,15 @@ func resetRootCmd() {
 	// Reset all persistent flags to defaults.
 	_ = rootCmd.PersistentFlags().Set("api-token", "")
 	_ = rootCmd.PersistentFlags().Set("format", "json")
+	_ = rootCmd.PersistentFlags().Lookup("columns").Value.(pflag.SliceValue).Replace(nil)
+	_ = rootCmd.PersistentFlags().Set("template", "")
 	_ = rootCmd.PersistentFlags().Set("per-page", "30")
 	_ = rootCmd.PersistentFlags().Set("all-pages", "false")
 	_ = rootCmd.PersistentFlags().Set("base-url", "https://api.bugsnag.com")
+	_ = rootCmd.PersistentFlags().Set("timeout", "30s")
+	_ = rootCmd.PersistentFlags().Set("max-retries", "3")
+	_ = rootCmd.PersistentFlags().Set("rate-limit", "10")
+	_ = rootCmd.PersistentFlags().Set("verbose", "false")
 
 	// Also clear any environment variables that might interfere.
 	os.Unsetenv("BUGSNAG_API_TOKEN")
@@ -53,8 +67,14 @@ func resetRootCmd() {
 	// Re-bind flags to viper since we reset viper.
 	_ = viper.BindPFlag("api_token", rootCmd.PersistentFlags().Lookup("api-token"))
 	_ = viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
+	_ = viper.BindPFlag("columns", rootCmd.PersistentFlags().Lookup("columns"))
+	_ = viper.BindPFlag("template", rootCmd.PersistentFlags().Lookup("template"))
 	_ = viper.BindPFlag("per_page", rootCmd.PersistentFlags().Lookup("per-page"))
 	_ = viper.BindPFlag("base_url", rootCmd.PersistentFlags().Lookup("base-url"))
+	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	_ = viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("rate_limit", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 
 	viper.SetDefault("format", "json")
 	viper.SetDefault("per_page", 30)
@@ -66,7 +86,13 @@ func resetRootCmd() {
 // do not leak into the next one.
 func resetSubcommandFlags(cmd *cobra.Command) {
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
-		_ = f.Value.Set(f.DefValue)
+		// Slice flags render their default as "[]", which Set would parse
+		// as a literal element, so replace their contents instead.
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
 		f.Changed = false
 	})
 	for _, child := range cmd.Commands() {
@@ -527,6 +553,405 @@ func TestErrorsGetCommand_MissingErrorID(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Errors update
+// ---------------------------------------------------------------------------
+
+func TestErrorsUpdateCommand(t *testing.T) {
+	resetRootCmd()
+	var body map[string]any
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors/e1": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&body)
+			respondJSON(w, 200, map[string]any{"id": "e1", "severity": "warning", "status": "open"})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-id", "e1",
+		"--operation", "override_severity",
+		"--new-severity", "warning",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["operation"] != "override_severity" || body["severity"] != "warning" {
+		t.Errorf("unexpected request body: %v", body)
+	}
+	if !strings.Contains(out, `"severity": "warning"`) {
+		t.Errorf("expected updated error in output, got: %q", out)
+	}
+}
+
+func TestErrorsUpdateCommand_SnoozeForDays(t *testing.T) {
+	resetRootCmd()
+	var body struct {
+		ReopenRules map[string]any `json:"reopen_rules"`
+	}
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors/e1": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&body)
+			respondJSON(w, 200, map[string]any{"id": "e1", "status": "snoozed"})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("errors", "update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-id", "e1",
+		"--operation", "snooze",
+		"--snooze-for", "2d",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.ReopenRules["reopen_if"] != "occurs_after" || body.ReopenRules["seconds"] != float64(172800) {
+		t.Errorf("unexpected reopen rules: %v", body.ReopenRules)
+	}
+}
+
+func TestErrorsUpdateCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing operation", nil, "--operation is required"},
+		{"unknown operation", []string{"--operation", "delete"}, "invalid --operation"},
+		{"severity missing", []string{"--operation", "override_severity"}, "--new-severity is required"},
+		{"severity invalid", []string{"--operation", "override_severity", "--new-severity", "fatal"}, "invalid --new-severity"},
+		{"assign without collaborator", []string{"--operation", "assign"}, "--collaborator-id is required"},
+		{"snooze without threshold", []string{"--operation", "snooze"}, "--snooze-for or --snooze-events is required"},
+		{"snooze with both thresholds", []string{"--operation", "snooze", "--snooze-for", "1h", "--snooze-events", "10"}, "mutually exclusive"},
+		{"snooze hours without events", []string{"--operation", "snooze", "--snooze-hours", "6"}, "--snooze-hours requires --snooze-events"},
+		{"snooze hours with duration", []string{"--operation", "snooze", "--snooze-for", "1h", "--snooze-hours", "6"}, "--snooze-hours requires --snooze-events"},
+		{"negative snooze events", []string{"--operation", "snooze", "--snooze-events", "-5"}, "invalid --snooze-events -5"},
+		{"negative snooze hours", []string{"--operation", "snooze", "--snooze-events", "5", "--snooze-hours", "-1"}, "invalid --snooze-hours -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"errors", "update", "--api-token", "tok", "--project-id", "p1", "--error-id", "e1"}, tt.args...)
+			_, err := executeCommandCapture(args...)
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"90m", 90 * time.Minute},
+		{"24h", 24 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.in)
+		if err != nil {
+			t.Errorf("parseDuration(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "d", "xd", "-1d", "5y"} {
+		if _, err := parseDuration(bad); err == nil {
+			t.Errorf("parseDuration(%q) expected error", bad)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Errors bulk-update
+// ---------------------------------------------------------------------------
+
+func TestErrorsBulkUpdateCommand_ErrorIDs(t *testing.T) {
+	resetRootCmd()
+	var gotIDs []string
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			gotIDs = append(gotIDs, r.URL.Query()["error_ids[]"]...)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", "e1,e2",
+		"--operation", "fix",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(gotIDs, ",") != "e1,e2" {
+		t.Errorf("unexpected error IDs sent: %v", gotIDs)
+	}
+	var result struct {
+		Data []map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(result.Data) != 2 || result.Data[0]["result"] != "updated" {
+		t.Errorf("unexpected results: %v", result.Data)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_Stdin(t *testing.T) {
+	resetRootCmd()
+	var gotIDs []string
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			gotIDs = append(gotIDs, r.URL.Query()["error_ids[]"]...)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	rootCmd.SetIn(strings.NewReader("e1\ne2\n\ne3\n"))
+	defer rootCmd.SetIn(nil)
+
+	_, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", "-",
+		"--operation", "ignore",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(gotIDs, ",") != "e1,e2,e3" {
+		t.Errorf("unexpected error IDs sent: %v", gotIDs)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_StdinCanceled(t *testing.T) {
+	resetRootCmd()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nothing is ever written to stdin: only the cancel can end the read.
+	stdin, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+	rootCmd.SetIn(stdin)
+	defer rootCmd.SetIn(nil)
+
+	rootCmd.SetArgs([]string{"errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", "-",
+		"--operation", "fix"})
+	errorsBulkUpdateCmd.SetContext(ctx)
+	err := rootCmd.ExecuteContext(ctx)
+	errorsBulkUpdateCmd.SetContext(context.Background())
+	rootCmd.SetContext(context.Background())
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_CancelStopsBatches(t *testing.T) {
+	resetRootCmd()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			cancel()
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	ids := make([]string, 60)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("e%d", i)
+	}
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", strings.Join(ids, ","),
+		"--operation", "fix",
+		"--rate-limit", "0",
+		"--base-url", srv.URL})
+	errorsBulkUpdateCmd.SetContext(ctx)
+	err := rootCmd.ExecuteContext(ctx)
+	w.Close()
+	os.Stdout = oldStdout
+	errorsBulkUpdateCmd.SetContext(context.Background())
+	rootCmd.SetContext(context.Background())
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no batch after the cancel, got %d requests", requests)
+	}
+	if !strings.Contains(buf.String(), `"total_count": 50`) {
+		t.Errorf("expected only the first batch to be reported, got: %q", buf.String())
+	}
+}
+
+func TestErrorsBulkUpdateCommand_FilterDryRun(t *testing.T) {
+	resetRootCmd()
+	patched := false
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("status") != "open" {
+				t.Errorf("expected status=open filter, got %q", r.URL.Query().Get("status"))
+			}
+			respondJSON(w, 200, []map[string]any{
+				{"id": "e1", "error_class": "TypeError"},
+				{"id": "e2", "error_class": "RangeError"},
+			})
+		},
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			patched = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--status", "open",
+		"--operation", "fix",
+		"--dry-run",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched {
+		t.Error("dry run must not send the bulk update")
+	}
+	if !strings.Contains(out, "would_update") || !strings.Contains(out, "RangeError") {
+		t.Errorf("expected dry-run results in output, got: %q", out)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_PartialFailure(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			for _, id := range r.URL.Query()["error_ids[]"] {
+				if id == "e55" {
+					respondJSON(w, 422, map[string]any{"errors": []map[string]string{{"message": "unknown error"}}})
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	ids := make([]string, 60)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("e%d", i)
+	}
+	out, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", strings.Join(ids, ","),
+		"--operation", "fix",
+		"--rate-limit", "0",
+		"--base-url", srv.URL)
+	if err == nil {
+		t.Fatal("expected error when a batch fails")
+	}
+	if !strings.Contains(err.Error(), "1 of 60 errors failed") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// The failed batch of 50 is retried one error at a time.
+	if strings.Count(out, `"result": "updated"`) != 59 || strings.Count(out, `"result": "failed"`) != 1 {
+		t.Errorf("expected 59 updated and 1 failed results, got: %q", out)
+	}
+	var resp struct {
+		Data []models.ErrorUpdateResult `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if r := resp.Data[55]; r.ErrorID != "e55" || r.Result != "failed" {
+		t.Errorf("expected e55 to fail, got %+v", r)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_BatchFailure(t *testing.T) {
+	resetRootCmd()
+	requests := 0
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"PATCH /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			respondJSON(w, 401, map[string]any{"errors": []map[string]string{{"message": "Unauthorized"}}})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", "e1,e2,e3",
+		"--operation", "fix",
+		"--rate-limit", "0",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "3 of 3 errors failed") {
+		t.Errorf("expected every error to fail, got: %v", err)
+	}
+	// A 401 applies to every ID, so the batch is not retried per error.
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+	if strings.Count(out, `"result": "failed"`) != 3 {
+		t.Errorf("expected 3 failed results, got: %q", out)
+	}
+}
+
+func TestErrorsBulkUpdateCommand_Validation(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--operation", "fix")
+	if err == nil || !strings.Contains(err.Error(), "--error-ids or at least one filter is required") {
+		t.Errorf("expected selection error, got: %v", err)
+	}
+
+	_, err = executeCommandCapture("errors", "bulk-update",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-ids", "e1",
+		"--status", "open",
+		"--operation", "fix")
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Events list
 // ---------------------------------------------------------------------------
@@ -590,6 +1015,102 @@ func TestEventsListCommand_MissingProjectID(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Filters (errors list / events list)
+// ---------------------------------------------------------------------------
+
+func TestErrorsListCommand_Filters(t *testing.T) {
+	resetRootCmd()
+	now = func() time.Time { return time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	var query url.Values
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			respondJSON(w, 200, []map[string]any{})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--filter", "app.version:eq:1.2.0",
+		"--filter", "user.email:ne:qa@example.com",
+		"--since", "24h",
+		"--release-stage", "production",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checks := map[string]string{
+		"filters[app.version][][value]":       "1.2.0",
+		"filters[user.email][][type]":         "ne",
+		"filters[event.since][][value]":       "2024-05-09T12:00:00Z",
+		"filters[app.release_stage][][value]": "production",
+	}
+	for k, want := range checks {
+		if got := query.Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestEventsListCommand_Filters(t *testing.T) {
+	resetRootCmd()
+	var query url.Values
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/errors/e1/events": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			respondJSON(w, 200, []map[string]any{})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("events", "list",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-id", "e1",
+		"--before", "2024-01-31T00:00:00Z",
+		"--filter", "device.os_name:eq:Android",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Get("filters[event.before][][value]") != "2024-01-31T00:00:00Z" {
+		t.Errorf("expected event.before filter, got %v", query)
+	}
+	if query.Get("filters[device.os_name][][value]") != "Android" {
+		t.Errorf("expected device.os_name filter, got %v", query)
+	}
+}
+
+func TestListCommands_InvalidFilter(t *testing.T) {
+	for _, args := range [][]string{
+		{"errors", "list", "--filter", "app.version"},
+		{"events", "list", "--since", "yesterday"},
+	} {
+		resetRootCmd()
+		_, err := executeCommandCapture(append(args, "--api-token", "tok", "--project-id", "p1")...)
+		if err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("%v: expected invalid filter error, got %v", args, err)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	ref := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	got, err := parseTime("7d", ref)
+	if err != nil || !got.Equal(ref.Add(-7*24*time.Hour)) {
+		t.Errorf("parseTime(7d) = %v, %v", got, err)
+	}
+	got, err = parseTime("2024-01-01T10:00:00+02:00", ref)
+	if err != nil || !got.Equal(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseTime(RFC3339) = %v, %v", got, err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Events get
 // ---------------------------------------------------------------------------
@@ -704,16 +1225,45 @@ func TestTrendsProjectCommand_TableFormat(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// Trends error
-// ---------------------------------------------------------------------------
-
-func TestTrendsErrorCommand(t *testing.T) {
+func TestTrendsProjectCommand_ChartFormat(t *testing.T) {
 	resetRootCmd()
+	t.Setenv("COLUMNS", "60")
 	srv := newMockServer(map[string]http.HandlerFunc{
-		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
 			respondJSON(w, 200, []map[string]any{
-				{"from": "2024-01-01", "to": "2024-01-02", "events_count": 7},
+				{"from": "2024-01-01", "to": "2024-01-02", "events_count": 10},
+				{"from": "2024-01-02", "to": "2024-01-03", "events_count": 30},
+				{"from": "2024-01-03", "to": "2024-01-04", "events_count": 20},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("trends", "project",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--format", "chart",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"events_count, 3 buckets", "30 +", " 0 +", "###", "min 10 (2024-01-01)  max 30 (2024-01-02)  avg 20"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Trends error
+// ---------------------------------------------------------------------------
+
+func TestTrendsErrorCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"from": "2024-01-01", "to": "2024-01-02", "events_count": 7},
 			})
 		},
 	})
@@ -755,6 +1305,385 @@ func TestTrendsErrorCommand_MissingErrorID(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Trends time range
+// ---------------------------------------------------------------------------
+
+func TestTrendsProjectCommand_Since(t *testing.T) {
+	resetRootCmd()
+	now = func() time.Time { return time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	var query url.Values
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			respondJSON(w, 200, []map[string]any{})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("trends", "project",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--since", "7d",
+		"--resolution", "1d",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := query.Get("filters[event.since][][value]"); got != "2024-05-03T12:00:00Z" {
+		t.Errorf("expected the range to start 7 days ago, got %q", got)
+	}
+	if query.Has("filters[event.before][][value]") {
+		t.Errorf("expected no end of range, got %v", query)
+	}
+	if got := query.Get("resolution"); got != "1d" {
+		t.Errorf("expected resolution 1d, got %q", got)
+	}
+}
+
+func TestTrendsErrorCommand_FromTo(t *testing.T) {
+	resetRootCmd()
+	var query url.Values
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			respondJSON(w, 200, []map[string]any{})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("trends", "error",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--error-id", "e1",
+		"--from", "2024-05-09T14:00:00+02:00",
+		"--to", "2024-05-09T18:00:00Z",
+		"--resolution", "30m",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checks := map[string]string{
+		"filters[event.since][][value]":  "2024-05-09T12:00:00Z",
+		"filters[event.before][][type]":  "eq",
+		"filters[event.before][][value]": "2024-05-09T18:00:00Z",
+		"resolution":                     "30m",
+	}
+	for k, want := range checks {
+		if got := query.Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestTrendsAnomaliesCommand_RangeAppliesToErrors(t *testing.T) {
+	resetRootCmd()
+	var errorQuery url.Values
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{})
+		},
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{{"id": "e1"}})
+		},
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			errorQuery = r.URL.Query()
+			respondJSON(w, 200, []map[string]any{})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("trends", "anomalies",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--from", "2024-05-01T00:00:00Z",
+		"--to", "2024-05-03T00:00:00Z",
+		"--resolution", "1h",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errorQuery.Get("resolution") != "1h" || errorQuery.Get("filters[event.since][][value]") != "2024-05-01T00:00:00Z" {
+		t.Errorf("expected the error trend to use the same buckets, got %v", errorQuery)
+	}
+}
+
+func TestTrendsCommand_InvalidRange(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--since", "7d", "--from", "2024-05-01T00:00:00Z"}, "--since and --from cannot be used together"},
+		{[]string{"--since", "soon"}, `invalid --since: "soon" is neither an RFC3339 time nor a duration`},
+		{[]string{"--from", "2024-05-01"}, `invalid --from "2024-05-01": expected an RFC3339 time`},
+		{[]string{"--to", "yesterday"}, `invalid --to "yesterday"`},
+		{[]string{"--from", "2024-05-02T00:00:00Z", "--to", "2024-05-01T00:00:00Z"}, "the time range is empty: 2024-05-02T00:00:00Z is not before 2024-05-01T00:00:00Z"},
+		{[]string{"--from", "2024-05-01T00:00:00Z", "--to", "2024-05-01T06:00:00Z", "--resolution", "1d"}, "--resolution 1d is longer than the 6h0m0s time range"},
+		{[]string{"--since", "2d", "--resolution", "1h", "--buckets-count", "10"}, "--resolution and --buckets-count cannot be used together with a time range"},
+		{[]string{"--to", "2024-05-01T00:00:00Z", "--resolution", "1h", "--buckets-count", "10"}, "--resolution and --buckets-count cannot be used together with a time range"},
+		{[]string{"--resolution", "fast"}, `invalid --resolution "fast"`},
+		{[]string{"--buckets-count", "-1"}, "--buckets-count must be positive"},
+	}
+	for _, tt := range tests {
+		for _, base := range [][]string{
+			{"trends", "project", "--api-token", "tok", "--project-id", "p1"},
+			{"trends", "error", "--api-token", "tok", "--project-id", "p1", "--error-id", "e1"},
+		} {
+			resetRootCmd()
+			_, err := executeCommandCapture(append(base, tt.args...)...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s %v: expected error containing %q, got %v", base[1], tt.args, tt.want, err)
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Trends anomalies
+// ---------------------------------------------------------------------------
+
+// anomaliesProjectTrend is a flat hourly project trend with a spike in the
+// last bucket.
+var anomaliesProjectTrend = []map[string]any{
+	{"from": "2024-05-01T00:00:00Z", "to": "2024-05-01T01:00:00Z", "events_count": 40},
+	{"from": "2024-05-01T01:00:00Z", "to": "2024-05-01T02:00:00Z", "events_count": 44},
+	{"from": "2024-05-01T02:00:00Z", "to": "2024-05-01T03:00:00Z", "events_count": 38},
+	{"from": "2024-05-01T03:00:00Z", "to": "2024-05-01T04:00:00Z", "events_count": 41},
+	{"from": "2024-05-01T04:00:00Z", "to": "2024-05-01T05:00:00Z", "events_count": 43},
+	{"from": "2024-05-01T05:00:00Z", "to": "2024-05-01T06:00:00Z", "events_count": 39},
+	{"from": "2024-05-01T06:00:00Z", "to": "2024-05-01T07:00:00Z", "events_count": 42},
+	{"from": "2024-05-01T07:00:00Z", "to": "2024-05-01T08:00:00Z", "events_count": 40},
+	{"from": "2024-05-01T08:00:00Z", "to": "2024-05-01T09:00:00Z", "events_count": 45},
+	{"from": "2024-05-01T09:00:00Z", "to": "2024-05-01T10:00:00Z", "events_count": 41},
+	{"from": "2024-05-01T10:00:00Z", "to": "2024-05-01T11:00:00Z", "events_count": 39},
+	{"from": "2024-05-01T11:00:00Z", "to": "2024-05-01T12:00:00Z", "events_count": 42},
+	{"from": "2024-05-01T12:00:00Z", "to": "2024-05-01T13:00:00Z", "events_count": 160},
+}
+
+// anomaliesSpikingTrend is the trend of error e1, which spikes at 10:00 and
+// in the last bucket.
+var anomaliesSpikingTrend = []map[string]any{
+	{"from": "2024-05-01T00:00:00Z", "to": "2024-05-01T01:00:00Z", "events_count": 10},
+	{"from": "2024-05-01T01:00:00Z", "to": "2024-05-01T02:00:00Z", "events_count": 12},
+	{"from": "2024-05-01T02:00:00Z", "to": "2024-05-01T03:00:00Z", "events_count": 11},
+	{"from": "2024-05-01T03:00:00Z", "to": "2024-05-01T04:00:00Z", "events_count": 9},
+	{"from": "2024-05-01T04:00:00Z", "to": "2024-05-01T05:00:00Z", "events_count": 10},
+	{"from": "2024-05-01T05:00:00Z", "to": "2024-05-01T06:00:00Z", "events_count": 12},
+	{"from": "2024-05-01T06:00:00Z", "to": "2024-05-01T07:00:00Z", "events_count": 11},
+	{"from": "2024-05-01T07:00:00Z", "to": "2024-05-01T08:00:00Z", "events_count": 10},
+	{"from": "2024-05-01T08:00:00Z", "to": "2024-05-01T09:00:00Z", "events_count": 12},
+	{"from": "2024-05-01T09:00:00Z", "to": "2024-05-01T10:00:00Z", "events_count": 11},
+	{"from": "2024-05-01T10:00:00Z", "to": "2024-05-01T11:00:00Z", "events_count": 58},
+	{"from": "2024-05-01T11:00:00Z", "to": "2024-05-01T12:00:00Z", "events_count": 12},
+	{"from": "2024-05-01T12:00:00Z", "to": "2024-05-01T13:00:00Z", "events_count": 120},
+}
+
+// anomaliesFlatTrend is the trend of error e2, which stays flat.
+var anomaliesFlatTrend = []map[string]any{
+	{"from": "2024-05-01T00:00:00Z", "to": "2024-05-01T01:00:00Z", "events_count": 30},
+	{"from": "2024-05-01T01:00:00Z", "to": "2024-05-01T02:00:00Z", "events_count": 29},
+	{"from": "2024-05-01T02:00:00Z", "to": "2024-05-01T03:00:00Z", "events_count": 31},
+	{"from": "2024-05-01T03:00:00Z", "to": "2024-05-01T04:00:00Z", "events_count": 30},
+	{"from": "2024-05-01T04:00:00Z", "to": "2024-05-01T05:00:00Z", "events_count": 32},
+	{"from": "2024-05-01T05:00:00Z", "to": "2024-05-01T06:00:00Z", "events_count": 28},
+	{"from": "2024-05-01T06:00:00Z", "to": "2024-05-01T07:00:00Z", "events_count": 30},
+	{"from": "2024-05-01T07:00:00Z", "to": "2024-05-01T08:00:00Z", "events_count": 31},
+	{"from": "2024-05-01T08:00:00Z", "to": "2024-05-01T09:00:00Z", "events_count": 29},
+	{"from": "2024-05-01T09:00:00Z", "to": "2024-05-01T10:00:00Z", "events_count": 30},
+	{"from": "2024-05-01T10:00:00Z", "to": "2024-05-01T11:00:00Z", "events_count": 31},
+	{"from": "2024-05-01T11:00:00Z", "to": "2024-05-01T12:00:00Z", "events_count": 30},
+	{"from": "2024-05-01T12:00:00Z", "to": "2024-05-01T13:00:00Z", "events_count": 29},
+}
+
+func TestTrendsAnomaliesCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesProjectTrend)
+		},
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("status") != "open" || q.Get("sort") != "events" || q.Get("direction") != "desc" {
+				t.Errorf("unexpected error list query %s", r.URL.RawQuery)
+			}
+			respondJSON(w, 200, []map[string]any{
+				{"id": "e1", "error_class": "TypeError", "message": "x is undefined"},
+				{"id": "e2", "error_class": "RangeError", "message": "too deep"},
+			})
+		},
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesSpikingTrend)
+		},
+		"GET /projects/p1/errors/e2/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesFlatTrend)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("trends", "anomalies",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--base-url", srv.URL)
+	if classifyError(err) != output.ExitCheckFailed {
+		t.Fatalf("expected anomalies to fail the check, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "anomalies found in project, error e1") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Data []models.TrendAnomaly `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(resp.Data) != 3 {
+		t.Fatalf("expected 3 anomalies, got %+v", resp.Data)
+	}
+	got := resp.Data[0]
+	if got.Scope != "project" || got.From != "2024-05-01T12:00:00Z" || got.EventsCount != 160 || got.Baseline != 41 || got.Score < 10 {
+		t.Errorf("unexpected project anomaly %+v", got)
+	}
+	for i, want := range []string{"2024-05-01T10:00:00Z", "2024-05-01T12:00:00Z"} {
+		a := resp.Data[i+1]
+		if a.Scope != "error" || a.ErrorID != "e1" || a.ErrorClass != "TypeError" || a.From != want {
+			t.Errorf("unexpected error anomaly %+v", a)
+		}
+	}
+}
+
+func TestTrendsAnomaliesCommand_Recent(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesProjectTrend)
+		},
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "e1", "error_class": "TypeError", "message": "x is undefined"},
+				{"id": "e2", "error_class": "RangeError", "message": "too deep"},
+			})
+		},
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesSpikingTrend)
+		},
+		"GET /projects/p1/errors/e2/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesFlatTrend)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("trends", "anomalies",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--recent", "1",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if classifyError(err) != output.ExitCheckFailed {
+		t.Fatalf("expected anomalies to fail the check, got %v", err)
+	}
+	if strings.Contains(out, "2024-05-01T10:00:00Z") {
+		t.Errorf("expected the older spike to be left out, got:\n%s", out)
+	}
+	for _, want := range []string{"SCOPE", "BASELINE", "project", "TypeError", "160"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTrendsAnomaliesCommand_ProjectOnly(t *testing.T) {
+	resetRootCmd()
+	calls := 0
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesProjectTrend)
+		},
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			respondJSON(w, 200, []map[string]any{
+				{"id": "e1", "error_class": "TypeError", "message": "x is undefined"},
+				{"id": "e2", "error_class": "RangeError", "message": "too deep"},
+			})
+		},
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesSpikingTrend)
+		},
+		"GET /projects/p1/errors/e2/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesFlatTrend)
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("trends", "anomalies",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--errors", "0",
+		"--method", "ewma",
+		"--base-url", srv.URL)
+	if err == nil || err.Error() != "anomalies found in project" {
+		t.Fatalf("expected a project anomaly, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected errors not to be listed, got %d calls", calls)
+	}
+}
+
+func TestTrendsAnomaliesCommand_None(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesProjectTrend)
+		},
+		"GET /projects/p1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "e1", "error_class": "TypeError", "message": "x is undefined"},
+				{"id": "e2", "error_class": "RangeError", "message": "too deep"},
+			})
+		},
+		"GET /projects/p1/errors/e1/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesSpikingTrend)
+		},
+		"GET /projects/p1/errors/e2/trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, anomaliesFlatTrend)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("trends", "anomalies",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--sensitivity", "100",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"data": []`) {
+		t.Errorf("expected an empty list, got: %s", out)
+	}
+}
+
+func TestTrendsAnomaliesCommand_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{}, "--project-id is required"},
+		{[]string{"--project-id", "p1", "--method", "zscore"}, `unknown method "zscore"`},
+		{[]string{"--project-id", "p1", "--window", "1"}, "window must be at least 2"},
+	}
+	for _, tt := range tests {
+		resetRootCmd()
+		args := append([]string{"trends", "anomalies", "--api-token", "tok"}, tt.args...)
+		_, err := executeCommandCapture(args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Collaborators list
 // ---------------------------------------------------------------------------
@@ -997,67 +1926,186 @@ func TestReleasesListCommand_TableFormat(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// Stability trend
-// ---------------------------------------------------------------------------
-
-func TestStabilityTrendCommand(t *testing.T) {
+func TestReleasesListCommand_Filters(t *testing.T) {
 	resetRootCmd()
 	srv := newMockServer(map[string]http.HandlerFunc{
-		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
-			respondJSON(w, 200, map[string]any{
-				"project_id":       "p1",
-				"release_stage_name": "production",
-				"timeline_points": []map[string]any{
-					{"bucket_start": "2024-01-01", "bucket_end": "2024-01-02", "total_sessions_count": 100, "unhandled_sessions_count": 5, "unhandled_rate": 0.05},
-				},
-			})
+		"GET /projects/p1/releases": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("release_stage") != "production" || r.URL.Query().Get("sort") != "timestamp" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			respondJSON(w, 200, []map[string]any{{"id": "r1", "app_version": "1.0.0"}})
 		},
 	})
 	defer srv.Close()
 
-	out, err := executeCommandCapture("stability", "trend",
+	out, err := executeCommandCapture("releases", "list",
 		"--api-token", "tok",
 		"--project-id", "p1",
+		"--release-stage", "production",
+		"--sort", "timestamp",
 		"--base-url", srv.URL)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if !strings.Contains(out, "p1") {
-		t.Errorf("expected p1 in output, got: %q", out)
-	}
-}
-
-func TestStabilityTrendCommand_MissingProjectID(t *testing.T) {
-	resetRootCmd()
-	_, err := executeCommandCapture("stability", "trend",
-		"--api-token", "tok")
-	if err == nil {
-		t.Fatal("expected error when --project-id missing")
-	}
-	if !strings.Contains(err.Error(), "--project-id is required") {
-		t.Errorf("unexpected error: %v", err)
+	if !strings.Contains(out, "r1") {
+		t.Errorf("expected r1 in output, got: %q", out)
 	}
 }
 
-func TestStabilityTrendCommand_TableFormat(t *testing.T) {
+func TestReleasesListCommand_ReleaseGroup(t *testing.T) {
 	resetRootCmd()
 	srv := newMockServer(map[string]http.HandlerFunc{
-		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
-			respondJSON(w, 200, map[string]any{
-				"project_id":       "p1",
-				"release_stage_name": "production",
-				"timeline_points": []map[string]any{
-					{"bucket_start": "2024-01-01", "bucket_end": "2024-01-02", "total_sessions_count": 100, "unhandled_sessions_count": 5, "unhandled_rate": 0.05},
-				},
-			})
+		"GET /release_groups/g1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{{"id": "r7", "app_version": "3.1.0"}})
 		},
 	})
 	defer srv.Close()
 
-	out, err := executeCommandCapture("stability", "trend",
+	out, err := executeCommandCapture("releases", "list",
 		"--api-token", "tok",
-		"--project-id", "p1",
+		"--release-group-id", "g1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "r7") {
+		t.Errorf("expected r7 in output, got: %q", out)
+	}
+
+	resetRootCmd()
+	_, err = executeCommandCapture("releases", "list",
+		"--api-token", "tok",
+		"--release-group-id", "g1",
+		"--sort", "timestamp")
+	if err == nil || !strings.Contains(err.Error(), "cannot be used with --release-group-id") {
+		t.Errorf("expected a conflicting flags error, got %v", err)
+	}
+}
+
+func TestReleasesGetCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /releases/r1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{"id": "r1", "app_version": "1.0.0", "release_stage": map[string]string{"name": "production"}})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "get",
+		"--api-token", "tok",
+		"--release-id", "r1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var release models.Release
+	if err := json.Unmarshal([]byte(out), &release); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if release.ID != "r1" || release.ReleaseStage.Name != "production" {
+		t.Errorf("unexpected release %+v", release)
+	}
+}
+
+func TestReleasesGetCommand_MissingReleaseID(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("releases", "get",
+		"--api-token", "tok")
+	if err == nil || !strings.Contains(err.Error(), "--release-id is required") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReleasesGroupsCommand_TableFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/release_groups": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("release_stage_name") != "staging" || r.URL.Query().Get("top_only") != "true" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			respondJSON(w, 200, []map[string]any{
+				{"id": "g1", "app_version": "2.0", "release_stage_name": "staging", "releases_count": 4, "top_release_group": true},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "groups",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--release-stage", "staging",
+		"--top-only",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "RELEASES") || !strings.Contains(out, "g1") || !strings.Contains(out, "yes") {
+		t.Errorf("expected the release group in a table, got: %q", out)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Stability trend
+// ---------------------------------------------------------------------------
+
+func TestStabilityTrendCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"project_id":       "p1",
+				"release_stage_name": "production",
+				"timeline_points": []map[string]any{
+					{"bucket_start": "2024-01-01", "bucket_end": "2024-01-02", "total_sessions_count": 100, "unhandled_sessions_count": 5, "unhandled_rate": 0.05},
+				},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("stability", "trend",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "p1") {
+		t.Errorf("expected p1 in output, got: %q", out)
+	}
+}
+
+func TestStabilityTrendCommand_MissingProjectID(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("stability", "trend",
+		"--api-token", "tok")
+	if err == nil {
+		t.Fatal("expected error when --project-id missing")
+	}
+	if !strings.Contains(err.Error(), "--project-id is required") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStabilityTrendCommand_TableFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"project_id":       "p1",
+				"release_stage_name": "production",
+				"timeline_points": []map[string]any{
+					{"bucket_start": "2024-01-01", "bucket_end": "2024-01-02", "total_sessions_count": 100, "unhandled_sessions_count": 5, "unhandled_rate": 0.05},
+				},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("stability", "trend",
+		"--api-token", "tok",
+		"--project-id", "p1",
 		"--format", "table",
 		"--base-url", srv.URL)
 	if err != nil {
@@ -1068,6 +2116,55 @@ func TestStabilityTrendCommand_TableFormat(t *testing.T) {
 	}
 }
 
+func TestStabilityTrendCommand_ChartFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"project_id": "p1",
+				"timeline_points": []map[string]any{
+					{"bucket_start": "2024-01-01", "unhandled_rate": 0.05},
+					{"bucket_start": "2024-01-02", "unhandled_rate": 0.0125},
+				},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("stability", "trend",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--format", "chart=sparkline",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"unhandled_rate #:\n", "2 buckets from 2024-01-01 to 2024-01-02", "max 0.05 (2024-01-01)", "avg 0.03125"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestProjectsGetCommand_ChartFormatUnsupported(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{"id": "p1"})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("projects", "get",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--format", "chart",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "the chart format is not supported") {
+		t.Errorf("expected an unsupported format error, got %v", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // --all-pages flag (pagination)
 // ---------------------------------------------------------------------------
@@ -1195,6 +2292,1710 @@ func TestCommand_APIError(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Retries (--max-retries)
+// ---------------------------------------------------------------------------
+
+func TestOrganizationsListCommand_MaxRetriesZero(t *testing.T) {
+	resetRootCmd()
+	calls := 0
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /user/organizations": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("organizations", "list",
+		"--api-token", "tok",
+		"--max-retries", "0",
+		"--base-url", srv.URL)
+	if err == nil {
+		t.Fatal("expected error on 503")
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call with --max-retries 0, got %d", calls)
+	}
+}
+
+func TestOrganizationsListCommand_VerboseRetryTotal(t *testing.T) {
+	resetRootCmd()
+	calls := 0
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /user/organizations": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= 2 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			respondJSON(w, 200, []map[string]any{{"id": "o1", "name": "Acme"}})
+		},
+	})
+	defer srv.Close()
+
+	run := func() string {
+		_, err := executeCommandCapture("organizations", "list",
+			"--api-token", "tok",
+			"--rate-limit", "0",
+			"--verbose",
+			"--base-url", srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		logRetries(&buf)
+		return buf.String()
+	}
+	if got := run(); got != "retries: 2\n" {
+		t.Errorf("expected the retry total, got %q", got)
+	}
+	// The next run starts from zero and, without retries, logs nothing.
+	if got := run(); got != "" {
+		t.Errorf("expected no retry total, got %q", got)
+	}
+}
+
+func TestNewClient_AppliesGlobalFlags(t *testing.T) {
+	resetRootCmd()
+	viper.Set("max_retries", 5)
+	viper.Set("verbose", true)
+	c := newClient("tok")
+	if c.MaxRetries != 5 {
+		t.Errorf("expected MaxRetries=5, got %d", c.MaxRetries)
+	}
+	if c.Log == nil {
+		t.Error("expected retry log to be enabled with --verbose")
+	}
+
+	viper.Set("max_retries", -1)
+	if got := getMaxRetries(); got != 0 {
+		t.Errorf("expected negative max retries to clamp to 0, got %d", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Cancellation and timeouts
+// ---------------------------------------------------------------------------
+
+func TestOrganizationsListCommand_CancelPrintsPartialList(t *testing.T) {
+	resetRootCmd()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var srvURL string
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /user/organizations": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/user/organizations?page=2>; rel="next"`, srvURL))
+			respondJSON(w, 200, []map[string]any{{"id": "org-1", "name": "First"}})
+		},
+	})
+	defer srv.Close()
+	srvURL = srv.URL
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	rootCmd.SetArgs([]string{"organizations", "list",
+		"--api-token", "tok",
+		"--all-pages",
+		"--base-url", srv.URL})
+	// Cobra only propagates the root context to subcommands that have none
+	// yet, and earlier tests already gave this one a background context.
+	organizationsListCmd.SetContext(ctx)
+	err := rootCmd.ExecuteContext(ctx)
+	w.Close()
+	os.Stdout = oldStdout
+	organizationsListCmd.SetContext(context.Background())
+	rootCmd.SetContext(context.Background())
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	var resp map[string]any
+	if jsonErr := json.Unmarshal(buf.Bytes(), &resp); jsonErr != nil {
+		t.Fatalf("expected partial JSON output, got %q: %v", buf.String(), jsonErr)
+	}
+	if resp["total_count"] != float64(1) {
+		t.Errorf("expected total_count=1, got %v", resp["total_count"])
+	}
+	if resp["has_more"] != true {
+		t.Errorf("expected has_more=true on a canceled list, got %v", resp["has_more"])
+	}
+}
+
+func TestOrganizationsListCommand_AllPagesErrorClosesPartialList(t *testing.T) {
+	resetRootCmd()
+	var srvURL string
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /user/organizations": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				respondJSON(w, 404, map[string]any{"errors": []string{"gone"}})
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/user/organizations?page=2>; rel="next"`, srvURL))
+			respondJSON(w, 200, []map[string]any{{"id": "org-1"}, {"id": "org-2"}})
+		},
+	})
+	defer srv.Close()
+	srvURL = srv.URL
+
+	out, err := executeCommandCapture("organizations", "list",
+		"--api-token", "tok",
+		"--all-pages",
+		"--base-url", srv.URL)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected the page 2 API error, got %v", err)
+	}
+
+	var resp struct {
+		Data       []models.Organization `json:"data"`
+		TotalCount int                   `json:"total_count"`
+		HasMore    bool                  `json:"has_more"`
+	}
+	if jsonErr := json.Unmarshal([]byte(out), &resp); jsonErr != nil {
+		t.Fatalf("expected the streamed list to stay valid JSON, got %q: %v", out, jsonErr)
+	}
+	if len(resp.Data) != 2 || resp.TotalCount != 2 || !resp.HasMore {
+		t.Errorf("expected 2 items with has_more, got %+v", resp)
+	}
+}
+
+func TestOrganizationsListCommand_EmptyList(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /user/organizations": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []any{})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("organizations", "list",
+		"--api-token", "tok",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"data": []`) || !strings.Contains(out, `"total_count": 0`) {
+		t.Errorf("expected an empty data array, got %q", out)
+	}
+}
+
+func TestNewClient_AppliesTimeout(t *testing.T) {
+	resetRootCmd()
+	viper.Set("timeout", 5*time.Second)
+	if got := newClient("tok").HTTPClient.Timeout; got != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %v", got)
+	}
+
+	viper.Set("timeout", 0)
+	if got := newClient("tok").HTTPClient.Timeout; got != 0 {
+		t.Errorf("expected --timeout 0 to disable the timeout, got %v", got)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NDJSON output
+// ---------------------------------------------------------------------------
+
+func TestEventsListCommand_NDJSON(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{{"id": "ev-1"}, {"id": "ev-2"}})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "ndjson",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per event, got %q", out)
+	}
+	for i, line := range lines {
+		var ev models.Event
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("line %d is not a JSON object: %q", i, line)
+		}
+		if want := fmt.Sprintf("ev-%d", i+1); ev.ID != want {
+			t.Errorf("line %d: expected id %s, got %s", i, want, ev.ID)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CSV and TSV output
+// ---------------------------------------------------------------------------
+
+func TestErrorsListCommand_CSVDefaultColumns(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "err-1", "error_class": "a, \"quoted\" class", "status": "open"},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "csv",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID,") {
+		t.Fatalf("expected header plus one row, got %q", out)
+	}
+	if !strings.Contains(lines[1], `"a, ""quoted"" class"`) {
+		t.Errorf("expected RFC 4180 quoting, got %q", lines[1])
+	}
+}
+
+func TestErrorsListCommand_TSVWithColumns(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "err-1", "error_class": "TypeError", "events": 12, "release_stages": []string{"production"}},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "tsv",
+		"--columns", "id,events,release_stages.0,missing",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id\tevents\trelease_stages.0\tmissing\nerr-1\t12\tproduction\t\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Template and JSONPath output
+// ---------------------------------------------------------------------------
+
+func TestErrorsListCommand_Template(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "err-1", "error_class": "TypeError", "events": 3},
+				{"id": "err-2", "error_class": "RangeError", "events": 12},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "template",
+		"--template", `{{range .}}{{.ID}} {{.ErrorClass | padRight 12}}|{{"\n"}}{{end}}`,
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "err-1 TypeError   |\nerr-2 RangeError  |\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+func TestErrorsListCommand_JSONPathInline(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "err-1", "error_class": "TypeError", "events": 3},
+				{"id": "err-2", "error_class": "RangeError", "events": 12},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", `jsonpath={.data[?(@.events>5)].id} {.total_count}`,
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "err-2 2" {
+		t.Errorf("expected %q, got %q", "err-2 2", out)
+	}
+}
+
+func TestErrorsListCommand_MissingTemplate(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []map[string]any{
+				{"id": "err-1", "error_class": "TypeError", "events": 3},
+				{"id": "err-2", "error_class": "RangeError", "events": 12},
+			})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "template",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "--template is required") {
+		t.Fatalf("expected a missing template error, got %v", err)
+	}
+	if code := classifyError(err); code != output.ExitConfig {
+		t.Errorf("expected exit code %d, got %d", output.ExitConfig, code)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Pretty output
+// ---------------------------------------------------------------------------
+
+func TestEventsGetCommand_PrettyFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events/ev-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"id":          "ev-1",
+				"error_class": "TypeError",
+				"message":     "x is undefined",
+				"exceptions": []map[string]any{{
+					"error_class": "TypeError",
+					"message":     "x is undefined",
+					"stacktrace": []map[string]any{
+						{"file": "src/app.js", "line_number": 10, "method": "render", "in_project": true},
+					},
+				}},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "get",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--format", "pretty",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "src/app.js:10 in render") {
+		t.Errorf("expected the stack frame, got:\n%s", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("expected no color when stdout is not a terminal")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Events breadcrumbs
+// ---------------------------------------------------------------------------
+
+func TestEventsBreadcrumbsCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events/ev-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"id":          "ev-1",
+				"received_at": "2024-05-24T10:00:10Z",
+				"breadcrumbs": []map[string]any{
+					{"timestamp": "2024-05-24T10:00:08Z", "name": "GET /api/cart", "type": "request", "meta_data": map[string]any{"status": 500}},
+					{"timestamp": "2024-05-24T10:00:01Z", "name": "/checkout", "type": "navigation"},
+					{"timestamp": "2024-05-24T10:00:09Z", "name": "retrying", "type": "log"},
+				},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "breadcrumbs",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--type", "request,navigation",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Data []struct {
+			Name               string         `json:"name"`
+			Type               string         `json:"type"`
+			MetaData           map[string]any `json:"meta_data"`
+			SecondsBeforeCrash float64        `json:"seconds_before_crash"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected the log breadcrumb to be filtered out, got %+v", resp.Data)
+	}
+	if resp.Data[0].Type != "navigation" || resp.Data[0].SecondsBeforeCrash != 9 {
+		t.Errorf("expected the navigation breadcrumb first, 9s before the crash, got %+v", resp.Data[0])
+	}
+	if resp.Data[1].MetaData["status"] != float64(500) {
+		t.Errorf("expected structured metadata to be kept, got %+v", resp.Data[1].MetaData)
+	}
+}
+
+func TestEventsBreadcrumbsCommand_InvalidType(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("events", "breadcrumbs",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--type", "click")
+	if err == nil || !strings.Contains(err.Error(), "invalid --type") {
+		t.Fatalf("expected an invalid type error, got %v", err)
+	}
+}
+
+func TestEventsBreadcrumbsCommand_MissingEventID(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("events", "breadcrumbs",
+		"--api-token", "tok",
+		"--project-id", "proj-1")
+	if err == nil || !strings.Contains(err.Error(), "--event-id is required") {
+		t.Fatalf("expected a missing event ID error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Events symbolicate
+// ---------------------------------------------------------------------------
+
+// symbolicateEvent has an in-project frame in app.min.js, covered by the map
+// written by writeSourceMaps, and a vendor frame without a map.
+var symbolicateEvent = map[string]any{
+	"id":          "ev-1",
+	"error_class": "TypeError",
+	"message":     "Cannot read properties of undefined (reading 'total')",
+	"exceptions": []map[string]any{{
+		"error_class": "TypeError",
+		"message":     "Cannot read properties of undefined (reading 'total')",
+		"stacktrace": []map[string]any{
+			{"file": "https://shop.acme.test/assets/app.min.js", "line_number": 1, "column_number": 48213, "method": "t", "in_project": true},
+			{"file": "https://shop.acme.test/assets/vendor.min.js", "line_number": 2, "column_number": 11876, "method": "commitRoot"},
+		},
+	}},
+}
+
+func writeSourceMaps(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "dist")
+	if err := os.MkdirAll(filepath.Join(dir, "assets"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sourceMap := map[string]any{
+		"version":        3,
+		"sources":        []string{"webpack:///./src/Summary.jsx"},
+		"sourcesContent": []string{"function renderSummary(cart) {\n  const totals = cart.totals;\n  return totals.total;\n}\n"},
+		"names":          []string{"renderSummary", "total"},
+		// Generated column 48200 maps to line 3, column 10, name "total".
+		"mappings": "AAAA,wk+CAESC",
+	}
+	data, _ := json.Marshal(sourceMap)
+	if err := os.WriteFile(filepath.Join(dir, "assets", "app.min.js.map"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestEventsSymbolicateCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events/ev-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, symbolicateEvent)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "symbolicate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--sourcemaps", writeSourceMaps(t),
+		"--context", "1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Data []models.SymbolicatedFrame `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 frames, got %+v", resp.Data)
+	}
+
+	orig := resp.Data[0].Original
+	if orig == nil || orig.File != "webpack:///./src/Summary.jsx" || orig.Line != 3 || orig.Column != 10 || orig.Name != "total" {
+		t.Fatalf("unexpected original position %+v", orig)
+	}
+	if len(orig.Code) != 3 || orig.Code[1].Number != 3 || orig.Code[1].Text != "  return totals.total;" {
+		t.Errorf("expected one line of context around the crash line, got %+v", orig.Code)
+	}
+	if resp.Data[1].Original != nil || resp.Data[1].Error != "no source map found" {
+		t.Errorf("expected the vendor frame to stay unresolved, got %+v", resp.Data[1])
+	}
+}
+
+func TestEventsSymbolicateCommand_Offline(t *testing.T) {
+	resetRootCmd()
+	eventFile := filepath.Join(t.TempDir(), "event.json")
+	data, _ := json.Marshal(symbolicateEvent)
+	if err := os.WriteFile(eventFile, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// No token and an unreachable base URL: everything comes from disk.
+	out, err := executeCommandCapture("events", "symbolicate",
+		"--event-file", eventFile,
+		"--sourcemaps", writeSourceMaps(t),
+		"--format", "pretty",
+		"--base-url", "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"→ webpack:///./src/Summary.jsx:3:10 in t",
+		"> 3 │   return totals.total;",
+		"... 1 vendor frame",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestEventsSymbolicateCommand_MissingSourceMaps(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("events", "symbolicate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1")
+	if err == nil || !strings.Contains(err.Error(), "--sourcemaps is required") {
+		t.Fatalf("expected a missing sourcemaps error, got %v", err)
+	}
+}
+
+func TestEventsSymbolicateCommand_MissingEvent(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("events", "symbolicate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--sourcemaps", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "--event-id or --event-file is required") {
+		t.Fatalf("expected a missing event error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Events get --with-source
+// ---------------------------------------------------------------------------
+
+// gitCommit commits files into a repository at dir, creating it if needed,
+// with a fixed identity and date, and returns the commit SHA.
+func gitCommit(t *testing.T, dir, subject, date string, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out []byte
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"commit", "-q", "-m", subject}, {"rev-parse", "HEAD"}} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+			"GIT_AUTHOR_NAME=Ada Lovelace", "GIT_AUTHOR_EMAIL=ada@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=Ada Lovelace", "GIT_COMMITTER_EMAIL=ada@example.com", "GIT_COMMITTER_DATE="+date,
+		)
+		var err error
+		if out, err = cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// sourceEvent crashes in src/cart.go, then goes through a runtime frame and
+// an in-project file that is no longer in the checkout.
+var sourceEvent = map[string]any{
+	"id":          "ev-1",
+	"error_class": "CartError",
+	"exceptions": []map[string]any{{
+		"error_class": "CartError",
+		"stacktrace": []map[string]any{
+			{"file": "/app/src/cart.go", "line_number": 4, "method": "cart.Total", "in_project": true},
+			{"file": "/usr/local/go/src/runtime/panic.go", "line_number": 770, "method": "runtime.gopanic"},
+			{"file": "/app/src/deleted.go", "line_number": 1, "method": "cart.Old", "in_project": true},
+		},
+	}},
+}
+
+func TestEventsGetCommand_WithSource(t *testing.T) {
+	resetRootCmd()
+	repo := t.TempDir()
+	gitCommit(t, repo, "Compute cart total", "2024-05-01T12:00:00Z", map[string]string{
+		"src/cart.go": "package cart\n\nfunc Total() int {\n\tpanic(\"empty cart\")\n}\n",
+	})
+
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events/ev-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, sourceEvent)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "get",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--blame",
+		"--source-root", repo,
+		"--path-prefix", "/app/=./",
+		"--context", "1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		ID           string               `json:"id"`
+		ErrorClass   string               `json:"error_class"`
+		SourceFrames []models.SourceFrame `json:"source_frames"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.ID != "ev-1" || resp.ErrorClass != "CartError" {
+		t.Errorf("expected the event fields to be kept, got %+v", resp)
+	}
+	if len(resp.SourceFrames) != 2 {
+		t.Fatalf("expected the two in-project frames, got %+v", resp.SourceFrames)
+	}
+
+	f := resp.SourceFrames[0]
+	if f.Path != "src/cart.go" || len(f.Code) != 3 || f.Code[1].Text != "\tpanic(\"empty cart\")" {
+		t.Errorf("unexpected source frame %+v", f)
+	}
+	if f.Blame == nil || f.Blame.Author != "Ada Lovelace" || f.Blame.Date != "2024-05-01" || f.Blame.Summary != "Compute cart total" {
+		t.Errorf("unexpected blame %+v", f.Blame)
+	}
+	if missing := resp.SourceFrames[1]; missing.Path != "" || !strings.HasPrefix(missing.Error, "not found under ") {
+		t.Errorf("expected the deleted file to be reported as not found, got %+v", missing)
+	}
+}
+
+func TestEventsGetCommand_WithoutSource(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/events/ev-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, sourceEvent)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("events", "get",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(out, "source_frames") {
+		t.Errorf("expected no source frames without --with-source, got:\n%s", out)
+	}
+}
+
+func TestEventsGetCommand_InvalidPathPrefix(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("events", "get",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--event-id", "ev-1",
+		"--with-source",
+		"--path-prefix", "/app/")
+	if err == nil || !strings.Contains(err.Error(), "invalid path prefix") {
+		t.Fatalf("expected an invalid path prefix error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Errors suspects
+// ---------------------------------------------------------------------------
+
+func testRelease(version, stage, at, revision string) models.Release {
+	r := models.Release{Version: version, ReleaseStage: models.ReleaseStage{Name: stage}, ReleaseTime: at}
+	if revision != "" {
+		r.SourceControl = &models.SourceControl{Revision: revision}
+	}
+	return r
+}
+
+func TestSuspectReleases(t *testing.T) {
+	releases := []models.Release{
+		testRelease("1.2.0", "production", "2024-05-10T09:00:00Z", "c3"),
+		testRelease("1.1.0-beta", "staging", "2024-05-04T09:00:00Z", "b1"),
+		testRelease("1.0.0", "production", "2024-05-01T09:00:00Z", "c1"),
+		testRelease("1.1.0", "production", "2024-05-05T09:00:00Z", "c2"),
+		testRelease("broken", "production", "not a time", "x"),
+	}
+	firstSeen := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	introduced, previous, err := suspectReleases(releases, firstSeen, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if introduced.Version != "1.1.0" || previous.Version != "1.0.0" {
+		t.Errorf("expected 1.0.0..1.1.0 skipping the staging release, got %s..%s", previous.Version, introduced.Version)
+	}
+
+	introduced, _, err = suspectReleases(releases, firstSeen, "staging")
+	if err == nil || introduced.Version != "1.1.0-beta" || !strings.Contains(err.Error(), "no release found before 1.1.0-beta") {
+		t.Errorf("expected no previous staging release, got %s, %v", introduced.Version, err)
+	}
+
+	if _, _, err := suspectReleases(releases, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), ""); err == nil {
+		t.Error("expected an error when the error predates every release")
+	}
+}
+
+func TestFramePathMatches(t *testing.T) {
+	tests := []struct {
+		repo, frame string
+		want        bool
+	}{
+		{"src/cart.go", "src/cart.go", true},
+		{"src/cart.go", "/app/src/cart.go", true},
+		{"app/src/main/java/com/acme/CheckoutActivity.kt", "CheckoutActivity.kt", true},
+		{"src/cart.go", "/app/src/oldcart.go", false},
+		{"src/cart.go", "src/cart.go.map", false},
+	}
+	for _, tt := range tests {
+		if got := framePathMatches(tt.repo, tt.frame); got != tt.want {
+			t.Errorf("framePathMatches(%q, %q) = %v, want %v", tt.repo, tt.frame, got, tt.want)
+		}
+	}
+}
+
+func TestErrorsSuspectsCommand(t *testing.T) {
+	resetRootCmd()
+	repo := t.TempDir()
+	v1 := gitCommit(t, repo, "Initial import", "2024-05-01T08:00:00Z", map[string]string{
+		"src/cart.go": "package cart\n", "src/util.go": "package cart\n", "README.md": "# shop\n",
+	})
+	gitCommit(t, repo, "Cache cart totals", "2024-05-02T08:00:00Z", map[string]string{"src/cart.go": "package cart // cached\n"})
+	gitCommit(t, repo, "Update docs", "2024-05-03T08:00:00Z", map[string]string{"README.md": "# shop v2\n"})
+	gitCommit(t, repo, "Round prices", "2024-05-03T09:00:00Z", map[string]string{"src/util.go": "package cart // rounded\n"})
+	v2 := gitCommit(t, repo, "Refactor checkout", "2024-05-04T08:00:00Z", map[string]string{
+		"src/cart.go": "package cart // refactored\n", "src/util.go": "package cart // refactored\n",
+	})
+
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors/err-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{"id": "err-1", "first_seen": "2024-05-06T10:00:00Z"})
+		},
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []models.Release{
+				testRelease("1.1.0", "production", "2024-05-05T09:00:00Z", v2),
+				testRelease("1.0.0", "production", "2024-05-01T09:00:00Z", v1),
+			})
+		},
+		"GET /projects/proj-1/errors/err-1/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{
+				"id": "ev-9",
+				"exceptions": []map[string]any{{
+					"error_class": "CartError",
+					"stacktrace": []map[string]any{
+						{"file": "/app/src/cart.go", "line_number": 1, "in_project": true},
+						{"file": "/usr/local/go/src/runtime/panic.go", "line_number": 770},
+						{"file": "/app/src/util.go", "line_number": 1, "in_project": true},
+					},
+				}},
+			})
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "suspects",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--error-id", "err-1",
+		"--repo", repo,
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Data []models.SuspectCommit `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	var subjects []string
+	for _, s := range resp.Data {
+		subjects = append(subjects, s.Subject)
+	}
+	if got := strings.Join(subjects, "|"); got != "Refactor checkout|Cache cart totals|Round prices" {
+		t.Fatalf("unexpected ranking %q", got)
+	}
+	top := resp.Data[0]
+	if top.SHA != v2 || top.Score != 1.5 || strings.Join(top.MatchedFiles, ",") != "src/cart.go,src/util.go" || top.Author != "Ada Lovelace" {
+		t.Errorf("unexpected top suspect %+v", top)
+	}
+}
+
+func TestErrorsSuspectsCommand_ReleaseWithoutRevision(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors/err-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{"id": "err-1", "first_seen": "2024-05-06T10:00:00Z"})
+		},
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []models.Release{
+				testRelease("1.1.0", "production", "2024-05-05T09:00:00Z", ""),
+				testRelease("1.0.0", "production", "2024-05-01T09:00:00Z", "abc"),
+			})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("errors", "suspects",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--error-id", "err-1",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "release 1.1.0 has no source control revision") {
+		t.Fatalf("expected a missing revision error, got %v", err)
+	}
+}
+
+func TestErrorsSuspectsCommand_OptionLikeRevision(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors/err-1": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, map[string]any{"id": "err-1", "first_seen": "2024-05-06T10:00:00Z"})
+		},
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, []models.Release{
+				testRelease("1.1.0", "production", "2024-05-05T09:00:00Z", "abc"),
+				testRelease("1.0.0", "production", "2024-05-01T09:00:00Z", "--output=/tmp/owned"),
+			})
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("errors", "suspects",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--error-id", "err-1",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), `release 1.0.0: invalid revision "--output=/tmp/owned"`) {
+		t.Fatalf("expected an invalid revision error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Errors owners
+// ---------------------------------------------------------------------------
+
+// ownersErrors are three errors whose latest events, built by
+// latestEventIn, crash in the payments code, in the checkout code, and in
+// no project code at all.
+var ownersErrors = []map[string]any{
+	{"id": "err-pay", "error_class": "ChargeError"},
+	{"id": "err-cart", "error_class": "CartError"},
+	{"id": "err-vendor", "error_class": "RouterError"},
+}
+
+// latestEventIn returns an event whose top frame is in a vendor file and
+// whose second frame is in file.
+func latestEventIn(file string, inProject bool) map[string]any {
+	return map[string]any{
+		"exceptions": []map[string]any{{
+			"stacktrace": []map[string]any{
+				{"file": "/usr/lib/node_modules/express/router.js", "line_number": 10},
+				{"file": file, "line_number": 42, "in_project": inProject},
+			},
+		}},
+	}
+}
+
+func writeCodeowners(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	path := filepath.Join(repo, ".github", "CODEOWNERS")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "*.js @acme/web\n/src/payments/ @acme/payments\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestErrorsOwnersCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, ownersErrors)
+		},
+		"GET /projects/proj-1/errors/err-pay/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/app/src/payments/charge.js", true))
+		},
+		"GET /projects/proj-1/errors/err-cart/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/app/src/checkout/cart.js", true))
+		},
+		"GET /projects/proj-1/errors/err-vendor/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/usr/lib/node_modules/express/index.js", false))
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "owners",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--repo", writeCodeowners(t),
+		"--path-prefix", "/app/=",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Data []models.ErrorOwnership `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(resp.Data) != 3 {
+		t.Fatalf("expected 3 errors, got %+v", resp.Data)
+	}
+
+	pay := resp.Data[0]
+	if pay.ErrorID != "err-pay" || pay.Path != "src/payments/charge.js" || strings.Join(pay.Owners, ",") != "@acme/payments" || strings.Join(pay.Rules, ",") != "/src/payments/" {
+		t.Errorf("unexpected ownership %+v", pay)
+	}
+	if cart := resp.Data[1]; strings.Join(cart.Owners, ",") != "@acme/web" {
+		t.Errorf("unexpected ownership %+v", cart)
+	}
+	if vendor := resp.Data[2]; len(vendor.Owners) != 0 || vendor.Error != "no in-project frame" {
+		t.Errorf("unexpected ownership %+v", vendor)
+	}
+}
+
+func TestErrorsListCommand_Owner(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, ownersErrors)
+		},
+		"GET /projects/proj-1/errors/err-pay/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/app/src/payments/charge.js", true))
+		},
+		"GET /projects/proj-1/errors/err-cart/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/app/src/checkout/cart.js", true))
+		},
+		"GET /projects/proj-1/errors/err-vendor/latest_event": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, latestEventIn("/usr/lib/node_modules/express/index.js", false))
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("errors", "list",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--owner", "@ACME/web",
+		"--repo", writeCodeowners(t),
+		"--path-prefix", "/app/=",
+		"--format", "ndjson",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"id":"err-cart"`) {
+		t.Errorf("expected only the checkout error, got:\n%s", out)
+	}
+}
+
+func TestErrorsOwnersCommand_MissingCodeowners(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("errors", "owners",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--repo", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "no CODEOWNERS file found") {
+		t.Fatalf("expected a missing CODEOWNERS error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Releases compare
+// ---------------------------------------------------------------------------
+
+// sessionRelease returns a release of version in stage with the given
+// session counts.
+func sessionRelease(version, stage, at string, total, unhandled int) map[string]any {
+	return map[string]any{
+		"app_version": version, "release_stage": map[string]any{"name": stage}, "release_time": at,
+		"total_sessions_count": total, "unhandled_sessions_count": unhandled,
+	}
+}
+
+// comparedReleases has 1.5.0 in production and staging and 1.4.0 in
+// production, and comparedErrors the errors seen in each version.
+var (
+	comparedReleases = []map[string]any{
+		sessionRelease("1.5.0", "production", "2024-05-10T09:00:00Z", 1000, 15),
+		sessionRelease("1.5.0", "staging", "2024-05-08T09:00:00Z", 1000, 5),
+		sessionRelease("1.4.0", "production", "2024-05-01T09:00:00Z", 4000, 40),
+	}
+	comparedErrors = map[string][]map[string]any{
+		"1.4.0": {
+			{"id": "e-both", "error_class": "Timeout", "first_seen_unfiltered": "2024-03-01T00:00:00Z"},
+			{"id": "e-gone", "error_class": "OldBug", "first_seen_unfiltered": "2024-04-01T00:00:00Z", "events": 7},
+		},
+		"1.5.0": {
+			{"id": "e-both", "error_class": "Timeout", "first_seen_unfiltered": "2024-03-01T00:00:00Z"},
+			{"id": "e-new", "error_class": "CartError", "first_seen": "2024-05-09T00:00:00Z", "first_seen_unfiltered": "2024-05-09T00:00:00Z", "events": 12},
+			{"id": "e-back", "error_class": "ZombieError", "first_seen": "2024-05-09T00:00:00Z", "first_seen_unfiltered": "2024-02-01T00:00:00Z"},
+		},
+	}
+)
+
+func TestReleasesCompareCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("filters[app.version][][type]") != "eq" {
+				t.Errorf("expected an app.version filter, got %s", r.URL.RawQuery)
+			}
+			respondJSON(w, 200, comparedErrors[q.Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "compare",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--from", "1.4.0",
+		"--to", "1.5.0",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cmp models.ReleaseComparison
+	if err := json.Unmarshal([]byte(out), &cmp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if cmp.To.TotalSessions != 2000 || cmp.To.UnhandledSessions != 20 || strings.Join(cmp.To.ReleaseStages, ",") != "production,staging" {
+		t.Errorf("expected 1.5.0 to add up both stages, got %+v", cmp.To)
+	}
+	if cmp.From.CrashRate == nil || *cmp.From.CrashRate != 1 || *cmp.From.CrashFreeRate != 99 {
+		t.Errorf("expected a 1%% crash rate for 1.4.0, got %+v", cmp.From)
+	}
+	if cmp.CrashRateChange == nil || *cmp.CrashRateChange != 0 {
+		t.Errorf("expected no crash rate change, got %v", cmp.CrashRateChange)
+	}
+	if cmp.From.ErrorsSeen != 2 || cmp.To.ErrorsSeen != 3 {
+		t.Errorf("unexpected error counts %d, %d", cmp.From.ErrorsSeen, cmp.To.ErrorsSeen)
+	}
+	if len(cmp.NewErrors) != 1 || cmp.NewErrors[0].ID != "e-new" || cmp.NewErrors[0].Events != 12 {
+		t.Errorf("unexpected new errors %+v", cmp.NewErrors)
+	}
+	if len(cmp.RegressedErrors) != 1 || cmp.RegressedErrors[0].ID != "e-back" || cmp.RegressedErrors[0].FirstSeen != "2024-02-01T00:00:00Z" {
+		t.Errorf("unexpected regressed errors %+v", cmp.RegressedErrors)
+	}
+	if len(cmp.ResolvedErrors) != 1 || cmp.ResolvedErrors[0].ID != "e-gone" {
+		t.Errorf("unexpected resolved errors %+v", cmp.ResolvedErrors)
+	}
+}
+
+func TestReleasesCompareCommand_TableFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedErrors[r.URL.Query().Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "compare",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--from", "1.4.0",
+		"--to", "1.5.0",
+		"--release-stage", "production",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"METRIC",
+		"crash_rate          1.00%   1.50%   +0.50 pts",
+		"CHANGE     ERROR_ID  ERROR_CLASS  EVENTS",
+		"new        e-new     CartError    12",
+		"regressed  e-back",
+		"resolved   e-gone",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestReleasesCompareCommand_UnknownVersion(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedErrors[r.URL.Query().Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("releases", "compare",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--from", "1.3.0",
+		"--to", "1.5.0",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "release 1.3.0 not found") {
+		t.Fatalf("expected a release not found error, got %v", err)
+	}
+}
+
+func TestReleasesCompareCommand_MissingTo(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("releases", "compare",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--from", "1.4.0")
+	if err == nil || !strings.Contains(err.Error(), "--to is required") {
+		t.Fatalf("expected a missing --to error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Releases gate
+// ---------------------------------------------------------------------------
+
+func TestReleasesGateCommand_Passes(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedErrors[r.URL.Query().Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "gate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--version", "1.5.0",
+		"--min-crash-free", "98.5",
+		"--max-new-errors", "1",
+		"--min-sessions", "2000",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gate models.ReleaseGate
+	if err := json.Unmarshal([]byte(out), &gate); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !gate.Passed || len(gate.Checks) != 3 {
+		t.Fatalf("expected three passing checks, got %+v", gate)
+	}
+	for _, check := range gate.Checks {
+		if !check.Passed || check.Actual == nil {
+			t.Errorf("expected %s to pass, got %+v", check.Name, check)
+		}
+	}
+	// e-back was first seen long before 1.5.0 and does not count as new.
+	if len(gate.NewErrors) != 1 || gate.NewErrors[0].ID != "e-new" {
+		t.Errorf("unexpected new errors %+v", gate.NewErrors)
+	}
+}
+
+func TestReleasesGateCommand_Fails(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedErrors[r.URL.Query().Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "gate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--version", "1.5.0",
+		"--min-crash-free", "99.5",
+		"--max-new-errors", "0",
+		"--min-sessions", "500",
+		"--base-url", srv.URL)
+	if err == nil {
+		t.Fatal("expected the gate to fail")
+	}
+	if code := classifyError(err); code != output.ExitCheckFailed {
+		t.Errorf("expected exit code %d, got %d", output.ExitCheckFailed, code)
+	}
+	if !strings.Contains(err.Error(), "min_crash_free, max_new_errors") {
+		t.Errorf("expected the failed checks in the error, got %v", err)
+	}
+
+	var gate models.ReleaseGate
+	if err := json.Unmarshal([]byte(out), &gate); err != nil {
+		t.Fatalf("expected the verdict on stdout: %v\n%s", err, out)
+	}
+	if gate.Passed {
+		t.Error("expected passed to be false")
+	}
+	got := map[string]bool{}
+	for _, check := range gate.Checks {
+		got[check.Name] = check.Passed
+	}
+	want := map[string]bool{"min_sessions": true, "min_crash_free": false, "max_new_errors": false}
+	if !maps.Equal(got, want) {
+		t.Errorf("expected checks %v, got %v", want, got)
+	}
+	if gate.Checks[1].Actual == nil || *gate.Checks[1].Actual != 99 {
+		t.Errorf("expected a 99%% crash-free rate, got %+v", gate.Checks[1])
+	}
+}
+
+func TestReleasesGateCommand_TableFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedReleases)
+		},
+		"GET /projects/proj-1/errors": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, comparedErrors[r.URL.Query().Get("filters[app.version][][value]")])
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("releases", "gate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--version", "1.4.0",
+		"--min-sessions", "5000",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if classifyError(err) != output.ExitCheckFailed {
+		t.Fatalf("expected a failed check, got %v", err)
+	}
+	for _, want := range []string{"CHECK", "min_sessions  FAIL    4000    5000", "4000 sessions, at least 5000 required"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCrashFreeCheck_NoSessions(t *testing.T) {
+	check := crashFreeCheck(models.ReleaseSummary{Version: "2.0.0"}, 99)
+	if check.Passed || check.Actual != nil {
+		t.Errorf("expected a release without sessions to fail, got %+v", check)
+	}
+}
+
+func TestReleasesGateCommand_MissingThreshold(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("releases", "gate",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--version", "1.5.0")
+	if err == nil || !strings.Contains(err.Error(), "one of --min-crash-free") {
+		t.Fatalf("expected a missing threshold error, got %v", err)
+	}
+	if code := classifyError(err); code != output.ExitConfig {
+		t.Errorf("expected exit code %d, got %d", output.ExitConfig, code)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Stability releases
+// ---------------------------------------------------------------------------
+
+// stabilityReleases has a 1.6.0 release without sessions, a 1.5.0 release
+// at 97% crash-free sessions and a 1.4.0 release at 99%.
+var stabilityReleases = []map[string]any{
+	{
+		"id": "r3", "app_version": "1.6.0", "release_stage": map[string]any{"name": "production"},
+		"total_sessions_count": 0, "unhandled_sessions_count": 0,
+		"accumulative_daily_users_seen": 0, "accumulative_daily_users_with_unhandled": 0,
+	},
+	{
+		"id": "r2", "app_version": "1.5.0", "release_stage": map[string]any{"name": "production"},
+		"total_sessions_count": 1000, "unhandled_sessions_count": 30,
+		"accumulative_daily_users_seen": 200, "accumulative_daily_users_with_unhandled": 10,
+	},
+	{
+		"id": "r1", "app_version": "1.4.0", "release_stage": map[string]any{"name": "production"},
+		"total_sessions_count": 2000, "unhandled_sessions_count": 20,
+		"accumulative_daily_users_seen": 400, "accumulative_daily_users_with_unhandled": 4,
+	},
+}
+
+func TestStabilityReleasesCommand(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			if stage := r.URL.Query().Get("release_stage"); stage != "production" {
+				t.Errorf("unexpected release_stage %q", stage)
+			}
+			respondJSON(w, 200, stabilityReleases)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("stability", "releases",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--release-stage", "production",
+		"--target", "99",
+		"--baseline", "1.4.0",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result struct {
+		Data []models.ReleaseStability `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Data) != 3 {
+		t.Fatalf("expected 3 releases, got %d", len(result.Data))
+	}
+
+	best, worst, unknown := result.Data[0], result.Data[1], result.Data[2]
+	if best.Version != "1.4.0" || best.Rank != 1 || *best.CrashFreeSessionRate != 99 || *best.CrashFreeUserRate != 99 {
+		t.Errorf("expected 1.4.0 first at 99%%, got %+v", best)
+	}
+	if best.BelowTarget || best.BaselineDelta == nil || *best.BaselineDelta != 0 {
+		t.Errorf("expected the baseline to meet the target with no delta, got %+v", best)
+	}
+	if worst.Version != "1.5.0" || worst.Rank != 2 || !worst.BelowTarget || *worst.BaselineDelta != -2 || *worst.CrashFreeUserRate != 95 {
+		t.Errorf("expected 1.5.0 second, below target, 2 points down, got %+v", worst)
+	}
+	if unknown.Version != "1.6.0" || unknown.Rank != 0 || unknown.CrashFreeSessionRate != nil || !unknown.BelowTarget || unknown.BaselineDelta != nil {
+		t.Errorf("expected 1.6.0 last and unranked, got %+v", unknown)
+	}
+}
+
+func TestStabilityReleasesCommand_TableFormat(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, stabilityReleases)
+		},
+	})
+	defer srv.Close()
+
+	out, err := executeCommandCapture("stability", "releases",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"CRASH_FREE_SESSIONS", "99.00%", "97.00%", "95.00%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "yes") {
+		t.Errorf("expected no release flagged without --target, got:\n%s", out)
+	}
+}
+
+func TestStabilityReleasesCommand_UnknownBaseline(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/proj-1/releases": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, stabilityReleases)
+		},
+	})
+	defer srv.Close()
+
+	_, err := executeCommandCapture("stability", "releases",
+		"--api-token", "tok",
+		"--project-id", "proj-1",
+		"--baseline", "0.9.0",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "baseline release 0.9.0 not found") {
+		t.Fatalf("expected a baseline not found error, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SLO status
+// ---------------------------------------------------------------------------
+
+// sloPoints returns daily buckets ending 2024-05-29, the last one first, with
+// 500 sessions each and the given unhandled sessions.
+func sloPoints(unhandled ...int) []models.TimelinePoint {
+	end := time.Date(2024, 5, 29, 0, 0, 0, 0, time.UTC)
+	var points []models.TimelinePoint
+	for i, u := range unhandled {
+		start := end.AddDate(0, 0, -i-1)
+		points = append(points, models.TimelinePoint{
+			BucketStart:            start.Format(time.RFC3339),
+			BucketEnd:              start.AddDate(0, 0, 1).Format(time.RFC3339),
+			TotalSessionsCount:     500,
+			UnhandledSessionsCount: u,
+		})
+	}
+	return points
+}
+
+func TestSLOStatus(t *testing.T) {
+	// Newest first: 8 crashes yesterday, 1 a day over the 6 days before,
+	// none over the rest of the 28 days, and a bad day just outside them.
+	unhandled := make([]int, 29)
+	unhandled[0] = 8
+	for i := 1; i <= 6; i++ {
+		unhandled[i] = 1
+	}
+	unhandled[28] = 500
+
+	windows := []burnWindow{{"1d", 24 * time.Hour}, {"7d", 7 * 24 * time.Hour}}
+	status := sloStatus(sloPoints(unhandled...), 99.8, 28*24*time.Hour, windows, 1)
+
+	if status.WindowStart != "2024-05-01T00:00:00Z" || status.WindowEnd != "2024-05-29T00:00:00Z" {
+		t.Errorf("unexpected window %s - %s", status.WindowStart, status.WindowEnd)
+	}
+	if status.TotalSessions != 14000 || status.UnhandledSessions != 14 || status.ErrorBudget != 28 {
+		t.Errorf("unexpected totals %+v", status)
+	}
+	if *status.BudgetConsumed != 50 || *status.BudgetRemaining != 50 || status.Exhausted {
+		t.Errorf("expected half the budget left, got %v consumed", *status.BudgetConsumed)
+	}
+	if len(status.BurnRates) != 2 || *status.BurnRates[0].Rate != 8 || *status.BurnRates[1].Rate != 2 {
+		t.Errorf("unexpected burn rates %+v", status.BurnRates)
+	}
+	if !status.BurnAlert || status.Status != "burning" {
+		t.Errorf("expected the SLO to be burning, got %s", status.Status)
+	}
+
+	// Above the threshold on the short window only: no alert.
+	status = sloStatus(sloPoints(unhandled...), 99.8, 28*24*time.Hour, windows, 4)
+	if status.BurnAlert || status.Status != "ok" {
+		t.Errorf("expected no alert with a threshold of 4, got %s", status.Status)
+	}
+
+	// Exactly the budget is exhausted.
+	status = sloStatus(sloPoints(28), 99.8, 28*24*time.Hour, windows, 1)
+	if !status.Exhausted || status.Status != "exhausted" || *status.BudgetRemaining != -2700 {
+		t.Errorf("expected an exhausted budget, got %+v", status)
+	}
+
+	// A budget that rounds to 0 is exhausted by one crash and untouched
+	// by none, instead of dividing by zero.
+	status = sloStatus(sloPoints(1), 99.99999999, 28*24*time.Hour, windows, 1)
+	if status.ErrorBudget != 0 || !status.Exhausted || *status.BudgetConsumed != 100 || *status.BudgetRemaining != 0 {
+		t.Errorf("expected a zero budget to be exhausted, got %+v", status)
+	}
+	status = sloStatus(sloPoints(0), 99.99999999, 28*24*time.Hour, windows, 1)
+	if status.Exhausted || *status.BudgetConsumed != 0 || *status.BudgetRemaining != 100 {
+		t.Errorf("expected a zero budget without crashes to be untouched, got %+v", status)
+	}
+
+	status = sloStatus(nil, 99.8, 28*24*time.Hour, windows, 1)
+	if status.BudgetConsumed != nil || status.Exhausted || status.BurnAlert || status.BurnRates[0].Rate != nil {
+		t.Errorf("expected nothing measured without sessions, got %+v", status)
+	}
+}
+
+func TestSLOStatusCommand_File(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/web/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("release_stage") != "production" {
+				t.Errorf("expected the release stage from the file, got %s", r.URL.RawQuery)
+			}
+			respondJSON(w, 200, models.StabilityTrend{ProjectID: "web", TimelinePoints: sloPoints(0, 0, 1)})
+		},
+		"GET /projects/api/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, models.StabilityTrend{ProjectID: "api", TimelinePoints: sloPoints(40, 30)})
+		},
+	})
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "slos.yaml")
+	defs := `slos:
+  - name: checkout-web
+    project_id: web
+    release_stage: production
+  - project_id: api
+    target: 99
+    window: 7d
+`
+	if err := os.WriteFile(file, []byte(defs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := executeCommandCapture("slo", "status",
+		"--api-token", "tok",
+		"--file", file,
+		"--target", "99.8",
+		"--base-url", srv.URL)
+	if classifyError(err) != output.ExitCheckFailed || !strings.Contains(err.Error(), "error budget exhausted: api") {
+		t.Fatalf("expected the api budget to be exhausted, got %v", err)
+	}
+
+	var result struct {
+		Data []models.SLOStatus `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Data) != 2 {
+		t.Fatalf("expected 2 SLOs, got %d", len(result.Data))
+	}
+	web, api := result.Data[0], result.Data[1]
+	if web.Name != "checkout-web" || web.Target != 99.8 || web.Window != "28d" || web.Status != "ok" || *web.BudgetRemaining != 66.666667 {
+		t.Errorf("unexpected web SLO %+v", web)
+	}
+	if api.Name != "api" || api.Target != 99 || api.Window != "7d" || api.Status != "exhausted" || *api.BudgetConsumed != 700 {
+		t.Errorf("unexpected api SLO %+v", api)
+	}
+}
+
+func TestSLOStatusCommand_APIError(t *testing.T) {
+	resetRootCmd()
+	srv := newMockServer(map[string]http.HandlerFunc{
+		"GET /projects/p1/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 200, models.StabilityTrend{TimelinePoints: sloPoints(0)})
+		},
+		"GET /projects/gone/stability_trend": func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, 404, map[string]any{"errors": []string{"Not found"}})
+		},
+	})
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "slos.json")
+	if err := os.WriteFile(file, []byte(`{"slos":[{"project_id":"p1"},{"project_id":"gone"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := executeCommandCapture("slo", "status",
+		"--api-token", "tok",
+		"--file", file,
+		"--target", "99.5",
+		"--format", "table",
+		"--base-url", srv.URL)
+	if err == nil || !strings.Contains(err.Error(), "could not check 1 of 2 SLOs: gone") {
+		t.Fatalf("expected a failed SLO, got %v", err)
+	}
+	if classifyError(err) == output.ExitCheckFailed {
+		t.Error("a failure to check is not a failed check")
+	}
+	for _, want := range []string{"BUDGET_REMAINING", "100.00%", "1d:0.00 7d:0.00", "error"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestSLOStatusCommand_MissingTarget(t *testing.T) {
+	resetRootCmd()
+	_, err := executeCommandCapture("slo", "status",
+		"--api-token", "tok",
+		"--project-id", "p1")
+	if err == nil || !strings.Contains(err.Error(), "--target is required") {
+		t.Fatalf("expected a missing target error, got %v", err)
+	}
+
+	_, err = executeCommandCapture("slo", "status",
+		"--api-token", "tok",
+		"--project-id", "p1",
+		"--target", "100")
+	if err == nil || !strings.Contains(err.Error(), "target must be between 0 and 100") {
+		t.Fatalf("expected an invalid target error, got %v", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // getPerPage edge cases
 // ---------------------------------------------------------------------------
@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
 var collaboratorsCmd = &cobra.Command{
@@ -27,18 +25,10 @@ var collaboratorsListCmd = &cobra.Command{
 			return fmt.Errorf("--org-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		collaborators, hasMore, err := c.ListCollaborators(orgID, getAllPages())
-		if err != nil {
-			return err
-		}
-
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(collaborators), len(collaborators), hasMore)
-		}
-		return p.PrintList(collaborators, len(collaborators), hasMore)
+		return streamList(cmd.Context(), p, c.CollaboratorsPager(orgID, getAllPages()))
 	},
 }
 
@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
 var commentsCmd = &cobra.Command{
@@ -32,18 +30,10 @@ var commentsListCmd = &cobra.Command{
 			return fmt.Errorf("--error-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		comments, hasMore, err := c.ListComments(projectID, errorID, getAllPages())
-		if err != nil {
-			return err
-		}
-
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(comments), len(comments), hasMore)
-		}
-		return p.PrintList(comments, len(comments), hasMore)
+		return streamList(cmd.Context(), p, c.CommentsPager(projectID, errorID, getAllPages()))
 	},
 }
 
@@ -71,10 +61,10 @@ var commentsCreateCmd = &cobra.Command{
 			return fmt.Errorf("--message is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		comment, err := c.CreateComment(projectID, errorID, message)
+		comment, err := c.CreateComment(cmd.Context(), projectID, errorID, message)
 		if err != nil {
 			return err
 		}
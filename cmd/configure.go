@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
 var configureCmd = &cobra.Command{
@@ -46,7 +45,7 @@ var configureCmd = &cobra.Command{
 			return fmt.Errorf("writing config file: %w", err)
 		}
 
-		p := output.NewPrinter(getFormat())
+		p := newPrinter()
 		result := map[string]string{
 			"status": "ok",
 			"path":   cfgPath,
@@ -57,7 +56,7 @@ var configureCmd = &cobra.Command{
 
 func init() {
 	configureCmd.Flags().StringP("api-token", "t", "", "Bugsnag API token (required)")
-	configureCmd.Flags().String("default-format", "", "Default output format (json or table)")
+	configureCmd.Flags().String("default-format", "", "Default output format (json, ndjson, csv, tsv, table, pretty, chart, template or jsonpath)")
 	configureCmd.Flags().String("default-base-url", "", "Default API base URL")
 	configureCmd.Flags().Int("default-per-page", 0, "Default results per page")
 
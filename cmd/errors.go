@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/codeowners"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/git"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
 var errorsCmd = &cobra.Command{
@@ -16,6 +25,11 @@ var errorsCmd = &cobra.Command{
 var errorsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List errors for a project",
+	Long: `List errors for a project.
+
+--owner keeps the errors owned by a CODEOWNERS owner (such as @acme/payments),
+matching the top in-project frame of each error's latest event like
+"errors owners". This fetches one event per error.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		token, err := getAPIToken()
 		if err != nil {
@@ -27,32 +41,99 @@ var errorsListCmd = &cobra.Command{
 			return fmt.Errorf("--project-id is required")
 		}
 
-		status, _ := cmd.Flags().GetString("status")
-		severity, _ := cmd.Flags().GetString("severity")
 		sort, _ := cmd.Flags().GetString("sort")
 		direction, _ := cmd.Flags().GetString("direction")
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
+
+		opts, err := errorFiltersFromFlags(cmd, projectID)
+		if err != nil {
+			return err
+		}
+		opts.Sort = sort
+		opts.Direction = direction
+		opts.AllPages = getAllPages()
+
+		owner, _ := cmd.Flags().GetString("owner")
+		if owner == "" {
+			return streamList(cmd.Context(), p, c.ErrorsPager(opts))
+		}
+
+		resolver, err := newOwnerResolver(cmd, c, projectID)
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		pager := c.ErrorsPager(opts)
+		owned := func(yield func(models.BugsnagError, error) bool) {
+			for e, err := range pager.All(ctx) {
+				if err != nil {
+					yield(e, err)
+					return
+				}
+				o, err := resolver.resolve(ctx, e)
+				if err != nil {
+					yield(e, err)
+					return
+				}
+				if codeowners.HasOwner(o.Owners, owner) && !yield(e, nil) {
+					return
+				}
+			}
+		}
+		return streamSeq(p, owned, pager.HasMore)
+	},
+}
+
+var errorsOwnersCmd = &cobra.Command{
+	Use:   "owners",
+	Short: "Show the CODEOWNERS owners of errors",
+	Long: `Match the top in-project frame of each error's latest event against the
+repository's CODEOWNERS file (GitHub or GitLab syntax) and print the owners.
+
+The file is looked up in .github/, the repository root, docs/ and .gitlab/
+unless --codeowners is given. Frame paths are resolved against --repo, with
+--path-prefix rewrites as for "events get --with-source". Errors are selected
+with the same filters as "errors list", and one event is fetched per error.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
 
-		opts := client.ListErrorsOptions{
-			ProjectID: projectID,
-			Status:    status,
-			Severity:  severity,
-			Sort:      sort,
-			Direction: direction,
-			AllPages:  getAllPages(),
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
 		}
 
-		errors, hasMore, err := c.ListErrors(opts)
+		opts, err := errorFiltersFromFlags(cmd, projectID)
 		if err != nil {
 			return err
 		}
+		opts.AllPages = getAllPages()
 
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(errors), len(errors), hasMore)
+		c := newClient(token)
+		p := newPrinter()
+
+		resolver, err := newOwnerResolver(cmd, c, projectID)
+		if err != nil {
+			return err
 		}
-		return p.PrintList(errors, len(errors), hasMore)
+		ctx := cmd.Context()
+		pager := c.ErrorsPager(opts)
+		ownerships := func(yield func(models.ErrorOwnership, error) bool) {
+			for e, err := range pager.All(ctx) {
+				if err != nil {
+					yield(models.ErrorOwnership{}, err)
+					return
+				}
+				if !yield(resolver.resolve(ctx, e)) {
+					return
+				}
+			}
+		}
+		return streamSeq(p, ownerships, pager.HasMore)
 	},
 }
 
@@ -75,10 +156,134 @@ var errorsGetCmd = &cobra.Command{
 			return fmt.Errorf("--error-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
+
+		bugsnagErr, err := c.GetError(cmd.Context(), projectID, errorID)
+		if err != nil {
+			return err
+		}
+
+		return p.PrintSingle(bugsnagErr)
+	},
+}
+
+var errorsSuspectsCmd = &cobra.Command{
+	Use:   "suspects",
+	Short: "Rank the commits most likely to have introduced an error",
+	Long: `Find the release an error first appeared in (the last release made before
+it was first seen) and the release before it, then walk the local git history
+between their revisions. Commits that changed files from the in-project frames
+of the error's latest event are ranked, files near the top of the stack trace
+counting most.
+
+Releases must carry a source control revision, and --repo must be a checkout
+that contains both revisions.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		errorID, _ := cmd.Flags().GetString("error-id")
+		if errorID == "" {
+			return fmt.Errorf("--error-id is required")
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		stage, _ := cmd.Flags().GetString("release-stage")
+
+		c := newClient(token)
+		p := newPrinter()
+		ctx := cmd.Context()
+
+		bugsnagErr, err := c.GetError(ctx, projectID, errorID)
+		if err != nil {
+			return err
+		}
+		firstSeen := bugsnagErr.FirstSeenUnfiltered
+		if firstSeen == "" {
+			firstSeen = bugsnagErr.FirstSeen
+		}
+		firstSeenAt, err := time.Parse(time.RFC3339, firstSeen)
+		if err != nil {
+			return fmt.Errorf("error %s has no valid first_seen time: %q", errorID, firstSeen)
+		}
+
+		releases, _, err := c.ListReleases(ctx, projectID, true)
+		if err != nil {
+			return err
+		}
+		introduced, previous, err := suspectReleases(releases, firstSeenAt, stage)
+		if err != nil {
+			return err
+		}
+		to, err := releaseRevision(introduced)
+		if err != nil {
+			return err
+		}
+		from, err := releaseRevision(previous)
+		if err != nil {
+			return err
+		}
+
+		event, err := c.GetLatestEvent(ctx, projectID, errorID)
+		if err != nil {
+			return err
+		}
+		exceptions, err := event.ParseExceptions()
+		if err != nil {
+			return fmt.Errorf("decoding exceptions: %w", err)
+		}
+
+		commits, err := git.Log(ctx, repo, from, to)
+		if err != nil {
+			return err
+		}
+
+		return printList(p, rankSuspects(commits, inProjectFiles(exceptions)))
+	},
+}
+
+var errorsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the status, severity or assignee of an error",
+	Long: `Apply a single operation to an error:
+
+  fix, open, ignore          change the error status
+  snooze                     snooze until --snooze-for elapses or --snooze-events occur
+  override_severity          set the severity given by --new-severity
+  assign, unassign           assign the collaborator given by --collaborator-id, or clear it`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		errorID, _ := cmd.Flags().GetString("error-id")
+		if errorID == "" {
+			return fmt.Errorf("--error-id is required")
+		}
+
+		update, err := errorUpdateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		c := newClient(token)
+		p := newPrinter()
 
-		bugsnagErr, err := c.GetError(projectID, errorID)
+		bugsnagErr, err := c.UpdateError(cmd.Context(), projectID, errorID, update)
 		if err != nil {
 			return err
 		}
@@ -87,17 +292,290 @@ var errorsGetCmd = &cobra.Command{
 	},
 }
 
+// bulkUpdateBatchSize bounds how many error IDs are sent in one bulk request
+// so the query string stays within common URL length limits.
+const bulkUpdateBatchSize = 50
+
+var errorsBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Apply one operation to many errors",
+	Long: `Apply one operation to a set of errors selected either by --error-ids
+(use "-" to read whitespace-separated IDs from stdin) or by the same filters
+as "errors list". Reports the result for each error and exits non-zero if
+any update failed. Use --dry-run to list the errors that would change.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		update, err := errorUpdateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		idsFlag, _ := cmd.Flags().GetString("error-ids")
+		if idsFlag == "-" {
+			data, err := readAllContext(cmd.Context(), cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("reading error IDs from stdin: %w", err)
+			}
+			idsFlag = string(data)
+		}
+		errorIDs := strings.FieldsFunc(idsFlag, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		opts, err := errorFiltersFromFlags(cmd, projectID)
+		if err != nil {
+			return err
+		}
+		filtered := opts.Status != "" || opts.Severity != "" || len(opts.Filters) > 0
+		if len(errorIDs) > 0 && filtered {
+			return fmt.Errorf("--error-ids cannot be combined with filters")
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+
+		var results []models.ErrorUpdateResult
+		switch {
+		case len(errorIDs) > 0:
+			for _, id := range errorIDs {
+				results = append(results, models.ErrorUpdateResult{ErrorID: id})
+			}
+		case filtered:
+			opts.AllPages = true
+			matches, _, err := c.ListErrors(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			for _, e := range matches {
+				results = append(results, models.ErrorUpdateResult{ErrorID: e.ID, ErrorClass: e.ErrorClass})
+			}
+		default:
+			return fmt.Errorf("--error-ids or at least one filter is required")
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		failed := 0
+		for start := 0; start < len(results); start += bulkUpdateBatchSize {
+			if err := cmd.Context().Err(); err != nil {
+				// Report the batches sent before Ctrl-C, not the rest.
+				if start > 0 {
+					_ = printList(p, results[:start])
+				}
+				return err
+			}
+			batch := results[start:min(start+bulkUpdateBatchSize, len(results))]
+			if dryRun {
+				for i := range batch {
+					batch[i].Result = "would_update"
+				}
+				continue
+			}
+			ids := make([]string, len(batch))
+			for i, r := range batch {
+				ids[i] = r.ErrorID
+			}
+			err := c.BulkUpdateErrors(cmd.Context(), projectID, ids, update)
+			// A batch rejected because of one of its errors is retried one
+			// error at a time so that each result says whether that error
+			// was updated; any other failure applies to the whole batch.
+			retryEach := err != nil && len(batch) > 1 && isPerErrorFailure(err)
+			for i := range batch {
+				itemErr := err
+				if retryEach && cmd.Context().Err() == nil {
+					itemErr = c.BulkUpdateErrors(cmd.Context(), projectID, ids[i:i+1], update)
+				}
+				setUpdateResult(&batch[i], itemErr)
+				if itemErr != nil {
+					failed++
+				}
+			}
+		}
+
+		if err := printList(p, results); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d errors failed to update", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// readAllContext reads r to EOF but returns ctx's error as soon as ctx is
+// canceled, so that Ctrl-C is not swallowed while waiting on stdin.
+func readAllContext(ctx context.Context, r io.Reader) ([]byte, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		data, err := io.ReadAll(r)
+		done <- result{data, err}
+	}()
+	select {
+	case res := <-done:
+		return res.data, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// isPerErrorFailure reports whether a failed bulk update may have been
+// caused by only some of its error IDs, as opposed to a failure such as
+// a bad token or missing project that every ID would hit again.
+func isPerErrorFailure(err error) bool {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity
+}
+
+// setUpdateResult records the outcome of updating r's error.
+func setUpdateResult(r *models.ErrorUpdateResult, err error) {
+	if err != nil {
+		r.Result = "failed"
+		r.Error = err.Error()
+		return
+	}
+	r.Result = "updated"
+}
+
+// errorFiltersFromFlags reads the filters registered by addErrorFilterFlags.
+func errorFiltersFromFlags(cmd *cobra.Command, projectID string) (client.ListErrorsOptions, error) {
+	status, _ := cmd.Flags().GetString("status")
+	severity, _ := cmd.Flags().GetString("severity")
+	filters, err := filtersFromFlags(cmd)
+	if err != nil {
+		return client.ListErrorsOptions{}, err
+	}
+	return client.ListErrorsOptions{
+		ProjectID: projectID,
+		Status:    status,
+		Severity:  severity,
+		Filters:   filters,
+	}, nil
+}
+
+// addErrorFilterFlags registers the error filters shared by commands that
+// select errors.
+func addErrorFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("status", "", "Filter by status (open, fixed, snoozed, ignored)")
+	cmd.Flags().String("severity", "", "Filter by severity (info, warning, error)")
+	addFilterFlags(cmd)
+}
+
+// errorUpdateFromFlags builds and validates an ErrorUpdate from the
+// --operation flag and its operation-specific companions.
+func errorUpdateFromFlags(cmd *cobra.Command) (client.ErrorUpdate, error) {
+	operation, _ := cmd.Flags().GetString("operation")
+	severity, _ := cmd.Flags().GetString("new-severity")
+	collaboratorID, _ := cmd.Flags().GetString("collaborator-id")
+	snoozeFor, _ := cmd.Flags().GetString("snooze-for")
+	snoozeEvents, _ := cmd.Flags().GetInt("snooze-events")
+	snoozeHours, _ := cmd.Flags().GetInt("snooze-hours")
+
+	update := client.ErrorUpdate{Operation: operation}
+	switch operation {
+	case "":
+		return update, fmt.Errorf("--operation is required")
+	case "fix", "open", "ignore", "unassign":
+	case "override_severity":
+		if severity == "" {
+			return update, fmt.Errorf("--new-severity is required for override_severity")
+		}
+		if severity != "info" && severity != "warning" && severity != "error" {
+			return update, fmt.Errorf("invalid --new-severity %q (expected info, warning or error)", severity)
+		}
+		update.Severity = severity
+	case "assign":
+		if collaboratorID == "" {
+			return update, fmt.Errorf("--collaborator-id is required for assign")
+		}
+		update.CollaboratorID = collaboratorID
+	case "snooze":
+		switch {
+		case snoozeEvents < 0:
+			return update, fmt.Errorf("invalid --snooze-events %d (expected a positive number)", snoozeEvents)
+		case snoozeHours < 0:
+			return update, fmt.Errorf("invalid --snooze-hours %d (expected a positive number)", snoozeHours)
+		case snoozeHours > 0 && snoozeEvents == 0:
+			return update, fmt.Errorf("--snooze-hours requires --snooze-events")
+		case snoozeFor != "" && snoozeEvents > 0:
+			return update, fmt.Errorf("--snooze-for and --snooze-events are mutually exclusive")
+		case snoozeFor != "":
+			d, err := parseDuration(snoozeFor)
+			if err != nil || d < time.Second {
+				return update, fmt.Errorf("invalid --snooze-for %q", snoozeFor)
+			}
+			update.ReopenRules = &models.ReopenRules{ReopenIf: "occurs_after", Seconds: int(d.Seconds())}
+		case snoozeEvents > 0 && snoozeHours > 0:
+			update.ReopenRules = &models.ReopenRules{ReopenIf: "n_occurrences_in_m_hours", Occurrences: snoozeEvents, Hours: snoozeHours}
+		case snoozeEvents > 0:
+			update.ReopenRules = &models.ReopenRules{ReopenIf: "n_additional_occurrences", AdditionalOccurrences: snoozeEvents}
+		default:
+			return update, fmt.Errorf("--snooze-for or --snooze-events is required for snooze")
+		}
+	default:
+		return update, fmt.Errorf("invalid --operation %q (expected fix, open, snooze, ignore, override_severity, assign or unassign)", operation)
+	}
+	return update, nil
+}
+
+// addErrorUpdateFlags registers the flags read by errorUpdateFromFlags.
+func addErrorUpdateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("operation", "", "Operation: fix, open, snooze, ignore, override_severity, assign, unassign (required)")
+	cmd.Flags().String("new-severity", "", "New severity for override_severity (info, warning, error)")
+	cmd.Flags().String("collaborator-id", "", "Collaborator ID for assign")
+	cmd.Flags().String("snooze-for", "", "Snooze: reopen after this duration (e.g. 6h, 7d)")
+	cmd.Flags().Int("snooze-events", 0, "Snooze: reopen after this many more events")
+	cmd.Flags().Int("snooze-hours", 0, "Snooze: with --snooze-events, only count events within this many hours")
+}
+
 func init() {
 	errorsListCmd.Flags().String("project-id", "", "Project ID (required)")
-	errorsListCmd.Flags().String("status", "", "Filter by status (open, fixed, snoozed, ignored)")
-	errorsListCmd.Flags().String("severity", "", "Filter by severity (info, warning, error)")
+	addErrorFilterFlags(errorsListCmd)
 	errorsListCmd.Flags().String("sort", "", "Sort field (created_at, last_seen, events, users, unsorted)")
 	errorsListCmd.Flags().String("direction", "", "Sort direction (asc, desc)")
+	errorsListCmd.Flags().String("owner", "", "Only errors owned by this CODEOWNERS owner (e.g. @acme/payments)")
+	addOwnerFlags(errorsListCmd)
+
+	errorsOwnersCmd.Flags().String("project-id", "", "Project ID (required)")
+	addErrorFilterFlags(errorsOwnersCmd)
+	addOwnerFlags(errorsOwnersCmd)
 
 	errorsGetCmd.Flags().String("project-id", "", "Project ID (required)")
 	errorsGetCmd.Flags().String("error-id", "", "Error ID (required)")
 
+	errorsSuspectsCmd.Flags().String("project-id", "", "Project ID (required)")
+	errorsSuspectsCmd.Flags().String("error-id", "", "Error ID (required)")
+	errorsSuspectsCmd.Flags().String("repo", ".", "Local git checkout to read the history from")
+	errorsSuspectsCmd.Flags().String("release-stage", "", "Only consider releases in this release stage")
+
+	errorsUpdateCmd.Flags().String("project-id", "", "Project ID (required)")
+	errorsUpdateCmd.Flags().String("error-id", "", "Error ID (required)")
+	addErrorUpdateFlags(errorsUpdateCmd)
+
 	errorsCmd.AddCommand(errorsListCmd)
 	errorsCmd.AddCommand(errorsGetCmd)
+	errorsCmd.AddCommand(errorsSuspectsCmd)
+	errorsCmd.AddCommand(errorsOwnersCmd)
+	errorsBulkUpdateCmd.Flags().String("project-id", "", "Project ID (required)")
+	errorsBulkUpdateCmd.Flags().String("error-ids", "", "Comma-separated error IDs, or - to read them from stdin")
+	errorsBulkUpdateCmd.Flags().Bool("dry-run", false, "List the errors that would be updated without changing them")
+	addErrorFilterFlags(errorsBulkUpdateCmd)
+	addErrorUpdateFlags(errorsBulkUpdateCmd)
+
+	errorsCmd.AddCommand(errorsUpdateCmd)
+	errorsCmd.AddCommand(errorsBulkUpdateCmd)
 	rootCmd.AddCommand(errorsCmd)
 }
@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/git"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/source"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/sourcemap"
 )
 
 var eventsCmd = &cobra.Command{
@@ -29,24 +41,33 @@ var eventsListCmd = &cobra.Command{
 
 		errorID, _ := cmd.Flags().GetString("error-id")
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
-
-		events, hasMore, err := c.ListEvents(projectID, errorID, getAllPages())
+		filters, err := filtersFromFlags(cmd)
 		if err != nil {
 			return err
 		}
 
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(events), len(events), hasMore)
-		}
-		return p.PrintList(events, len(events), hasMore)
+		c := newClient(token)
+		p := newPrinter()
+
+		return streamList(cmd.Context(), p, c.EventsPager(client.ListEventsOptions{
+			ProjectID: projectID,
+			ErrorID:   errorID,
+			Filters:   filters,
+			AllPages:  getAllPages(),
+		}))
 	},
 }
 
 var eventsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get an event by ID",
+	Long: `Get an event by ID.
+
+With --with-source, each in-project frame is matched to a file under
+--source-root and the surrounding lines are read from disk. Use --path-prefix
+(or path_prefixes in the config file) to map the paths the app was built with
+to the local checkout, and --blame to show the commit that last changed each
+crashing line.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		token, err := getAPIToken()
 		if err != nil {
@@ -63,26 +84,309 @@ var eventsGetCmd = &cobra.Command{
 			return fmt.Errorf("--event-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		withSource, _ := cmd.Flags().GetBool("with-source")
+		blame, _ := cmd.Flags().GetBool("blame")
+		var locator source.Locator
+		if withSource || blame {
+			root, _ := cmd.Flags().GetString("source-root")
+			if locator, err = locatorFromFlags(cmd, root); err != nil {
+				return err
+			}
+		}
+		radius, _ := cmd.Flags().GetInt("context")
+		if radius < 0 {
+			return fmt.Errorf("--context must not be negative")
+		}
+
+		c := newClient(token)
+		p := newPrinter()
 
-		event, err := c.GetEvent(projectID, eventID)
+		event, err := c.GetEvent(cmd.Context(), projectID, eventID)
 		if err != nil {
 			return err
 		}
 
-		return p.PrintSingle(event)
+		if !withSource && !blame {
+			return p.PrintSingle(event)
+		}
+
+		exceptions, err := event.ParseExceptions()
+		if err != nil {
+			return fmt.Errorf("decoding exceptions: %w", err)
+		}
+		return p.PrintSingle(models.EventWithSource{
+			Event:        event,
+			SourceFrames: sourceFrames(cmd.Context(), exceptions, locator, radius, blame),
+		})
 	},
 }
 
+// locatorFromFlags builds a source locator for the checkout at root from
+// --path-prefix, falling back to path_prefixes from the config file.
+func locatorFromFlags(cmd *cobra.Command, root string) (source.Locator, error) {
+	prefixes, _ := cmd.Flags().GetStringSlice("path-prefix")
+	if !cmd.Flags().Changed("path-prefix") {
+		prefixes = viper.GetStringSlice("path_prefixes")
+	}
+
+	l := source.Locator{Root: root}
+	for _, s := range prefixes {
+		r, err := source.ParseRewrite(s)
+		if err != nil {
+			return source.Locator{}, err
+		}
+		l.Rewrites = append(l.Rewrites, r)
+	}
+	return l, nil
+}
+
+// sourceFrames reads the code around each in-project frame from the local
+// checkout and, with blame, the commit that last changed the frame's line.
+func sourceFrames(ctx context.Context, exceptions []models.Exception, l source.Locator, radius int, blame bool) []models.SourceFrame {
+	frames := []models.SourceFrame{}
+	for i, ex := range exceptions {
+		for _, f := range ex.Stacktrace {
+			if !f.InProject {
+				continue
+			}
+			sf := models.SourceFrame{Exception: i, File: f.File, LineNumber: f.LineNumber, Method: f.Method}
+
+			path, ok := l.Locate(f.File)
+			if !ok {
+				sf.Error = "not found under " + l.Root
+				frames = append(frames, sf)
+				continue
+			}
+			sf.Path = filepath.ToSlash(path)
+
+			code, err := source.Snippet(filepath.Join(l.Root, path), f.LineNumber, radius)
+			if err != nil {
+				sf.Error = err.Error()
+			}
+			sf.Code = code
+
+			if blame && err == nil && f.LineNumber > 0 {
+				if c, err := git.Blame(ctx, l.Root, path, f.LineNumber); err != nil {
+					sf.Error = err.Error()
+				} else {
+					sf.Blame = &models.Blame{
+						Commit:  c.SHA,
+						Author:  c.Author,
+						Email:   c.Email,
+						Date:    c.Time.Format(time.DateOnly),
+						Summary: c.Subject,
+					}
+				}
+			}
+			frames = append(frames, sf)
+		}
+	}
+	return frames
+}
+
+// breadcrumbTypes are the breadcrumb types defined by Bugsnag notifiers.
+var breadcrumbTypes = []string{"navigation", "request", "process", "log", "user", "state", "error", "manual"}
+
+var eventsBreadcrumbsCmd = &cobra.Command{
+	Use:   "breadcrumbs",
+	Short: "Show the breadcrumb timeline leading to an event",
+	Long: `Show an event's breadcrumbs oldest first, with how long before the crash
+each one happened. Use --type to keep only some breadcrumb types.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		eventID, _ := cmd.Flags().GetString("event-id")
+		if eventID == "" {
+			return fmt.Errorf("--event-id is required")
+		}
+
+		types, _ := cmd.Flags().GetStringSlice("type")
+		for _, t := range types {
+			if !slices.Contains(breadcrumbTypes, t) {
+				return fmt.Errorf("invalid --type %q: must be one of %s", t, strings.Join(breadcrumbTypes, ", "))
+			}
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+
+		event, err := c.GetEvent(cmd.Context(), projectID, eventID)
+		if err != nil {
+			return err
+		}
+
+		timeline, err := event.BreadcrumbTimeline()
+		if err != nil {
+			return fmt.Errorf("decoding breadcrumbs: %w", err)
+		}
+		if len(types) > 0 {
+			timeline = slices.DeleteFunc(timeline, func(b models.BreadcrumbEntry) bool {
+				return !slices.Contains(types, b.Type)
+			})
+		}
+
+		return printList(p, timeline)
+	},
+}
+
+var eventsSymbolicateCmd = &cobra.Command{
+	Use:   "symbolicate",
+	Short: "Map minified JavaScript frames back to the original source",
+	Long: `Resolve the frames of an event's exceptions through the Source Map v3 files
+found under --sourcemaps, and show the original file, line and column with
+the surrounding code.
+
+Source maps are only read from disk. Pass --event-file with an event saved by
+"events get" to run without reaching the Bugsnag API at all.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("sourcemaps")
+		if dir == "" {
+			return fmt.Errorf("--sourcemaps is required")
+		}
+		radius, _ := cmd.Flags().GetInt("context")
+		if radius < 0 {
+			return fmt.Errorf("--context must not be negative")
+		}
+
+		event, err := loadEvent(cmd)
+		if err != nil {
+			return err
+		}
+
+		resolver, err := sourcemap.NewResolver(dir)
+		if err != nil {
+			return fmt.Errorf("reading source maps: %w", err)
+		}
+
+		exceptions, err := event.ParseExceptions()
+		if err != nil {
+			return fmt.Errorf("decoding exceptions: %w", err)
+		}
+		frames := symbolicateExceptions(exceptions, resolver, radius)
+
+		p := newPrinter()
+		if p.Format == "pretty" {
+			// Show the whole event with its frames rewritten, so the
+			// original code is rendered under each frame.
+			raw, err := json.Marshal(exceptions)
+			if err != nil {
+				return err
+			}
+			event.Exceptions = raw
+			return p.PrintSingle(event)
+		}
+		return printList(p, frames)
+	},
+}
+
+// loadEvent reads the event from --event-file, or fetches --event-id from
+// the API.
+func loadEvent(cmd *cobra.Command) (*models.Event, error) {
+	if path, _ := cmd.Flags().GetString("event-file"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var event models.Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", path, err)
+		}
+		return &event, nil
+	}
+
+	token, err := getAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, _ := cmd.Flags().GetString("project-id")
+	if projectID == "" {
+		return nil, fmt.Errorf("--project-id is required")
+	}
+
+	eventID, _ := cmd.Flags().GetString("event-id")
+	if eventID == "" {
+		return nil, fmt.Errorf("--event-id or --event-file is required")
+	}
+
+	return newClient(token).GetEvent(cmd.Context(), projectID, eventID)
+}
+
+// symbolicateExceptions resolves every frame of the exceptions, rewriting
+// resolved frames in place with their original position and code.
+func symbolicateExceptions(exceptions []models.Exception, r *sourcemap.Resolver, radius int) []models.SymbolicatedFrame {
+	var frames []models.SymbolicatedFrame
+	for i := range exceptions {
+		for j := range exceptions[i].Stacktrace {
+			f := &exceptions[i].Stacktrace[j]
+			sf := models.SymbolicatedFrame{
+				Exception:    i,
+				File:         f.File,
+				LineNumber:   f.LineNumber,
+				ColumnNumber: f.ColumnNumber,
+				Method:       f.Method,
+			}
+
+			pos, err := r.Resolve(f.File, f.LineNumber, f.ColumnNumber, radius)
+			if err != nil {
+				sf.Error = err.Error()
+				frames = append(frames, sf)
+				continue
+			}
+
+			sf.Original = &models.OriginalPosition{
+				File:   pos.Source,
+				Line:   pos.Line,
+				Column: pos.Column,
+				Name:   pos.Name,
+			}
+			code := make(map[string]string, len(pos.Context))
+			for _, l := range pos.Context {
+				sf.Original.Code = append(sf.Original.Code, models.CodeLine{Number: l.Number, Text: l.Text})
+				code[strconv.Itoa(l.Number)] = l.Text
+			}
+			frames = append(frames, sf)
+
+			f.File, f.LineNumber, f.ColumnNumber, f.Code = pos.Source, pos.Line, pos.Column, code
+		}
+	}
+	return frames
+}
+
 func init() {
 	eventsListCmd.Flags().String("project-id", "", "Project ID (required)")
 	eventsListCmd.Flags().String("error-id", "", "Error ID (optional, scope events to an error)")
+	addFilterFlags(eventsListCmd)
 
 	eventsGetCmd.Flags().String("project-id", "", "Project ID (required)")
 	eventsGetCmd.Flags().String("event-id", "", "Event ID (required)")
+	eventsGetCmd.Flags().Bool("with-source", false, "Show the code around in-project frames from the local checkout")
+	eventsGetCmd.Flags().String("source-root", ".", "Root of the local checkout used by --with-source")
+	eventsGetCmd.Flags().StringSlice("path-prefix", nil, "Rewrite frame path prefixes, as FROM=TO (e.g. /app/=./)")
+	eventsGetCmd.Flags().Int("context", 3, "Lines of source to show around each frame")
+	eventsGetCmd.Flags().Bool("blame", false, "Show the commit that last changed each crashing line (implies --with-source)")
 
 	eventsCmd.AddCommand(eventsListCmd)
+	eventsBreadcrumbsCmd.Flags().String("project-id", "", "Project ID (required)")
+	eventsBreadcrumbsCmd.Flags().String("event-id", "", "Event ID (required)")
+	eventsBreadcrumbsCmd.Flags().StringSlice("type", nil, "Only show these breadcrumb types: "+strings.Join(breadcrumbTypes, ", "))
+
+	eventsSymbolicateCmd.Flags().String("project-id", "", "Project ID (required with --event-id)")
+	eventsSymbolicateCmd.Flags().String("event-id", "", "Event ID to fetch from the API")
+	eventsSymbolicateCmd.Flags().String("event-file", "", "Read the event from a JSON file instead of the API")
+	eventsSymbolicateCmd.Flags().String("sourcemaps", "", "Directory searched for .map files (required)")
+	eventsSymbolicateCmd.Flags().Int("context", 3, "Lines of original source to show around each frame")
+
 	eventsCmd.AddCommand(eventsGetCmd)
+	eventsCmd.AddCommand(eventsBreadcrumbsCmd)
+	eventsCmd.AddCommand(eventsSymbolicateCmd)
 	rootCmd.AddCommand(eventsCmd)
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
+)
+
+// now is the clock used to resolve relative times; tests replace it.
+var now = time.Now
+
+// addFilterFlags registers the generic Bugsnag filter flags shared by the
+// errors and events list commands.
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("filter", nil, "Bugsnag filter as field:op:value, op is eq, ne or empty (repeatable)")
+	cmd.Flags().String("since", "", "Only include events after this time (RFC3339 or relative, e.g. 24h, 7d)")
+	cmd.Flags().String("before", "", "Only include events before this time (RFC3339 or relative, e.g. 24h, 7d)")
+	cmd.Flags().String("release-stage", "", "Only include events from this release stage")
+}
+
+// filtersFromFlags reads the flags registered by addFilterFlags and converts
+// them into API filters.
+func filtersFromFlags(cmd *cobra.Command) ([]client.Filter, error) {
+	var filters []client.Filter
+
+	exprs, _ := cmd.Flags().GetStringArray("filter")
+	for _, expr := range exprs {
+		f, err := client.ParseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	for _, tf := range []struct{ flag, field string }{
+		{"since", "event.since"},
+		{"before", "event.before"},
+	} {
+		v, _ := cmd.Flags().GetString(tf.flag)
+		if v == "" {
+			continue
+		}
+		t, err := parseTime(v, now())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", tf.flag, err)
+		}
+		filters = append(filters, client.Filter{Field: tf.field, Op: "eq", Value: t.UTC().Format(time.RFC3339)})
+	}
+
+	if stage, _ := cmd.Flags().GetString("release-stage"); stage != "" {
+		filters = append(filters, client.Filter{Field: "app.release_stage", Op: "eq", Value: stage})
+	}
+
+	return filters, nil
+}
+
+// parseTime accepts an RFC3339 timestamp or a duration relative to ref
+// (e.g. "24h" or "7d" meaning 24 hours or 7 days before ref).
+func parseTime(s string, ref time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC3339 time nor a duration", s)
+	}
+	return ref.Add(-d), nil
+}
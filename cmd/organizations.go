@@ -2,8 +2,6 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
 var organizationsCmd = &cobra.Command{
@@ -20,18 +18,10 @@ var organizationsListCmd = &cobra.Command{
 			return err
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		orgs, hasMore, err := c.ListOrganizations(getAllPages())
-		if err != nil {
-			return err
-		}
-
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(orgs), len(orgs), hasMore)
-		}
-		return p.PrintList(orgs, len(orgs), hasMore)
+		return streamList(cmd.Context(), p, c.OrganizationsPager(getAllPages()))
 	},
 }
 
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/codeowners"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/source"
+)
+
+// ownerResolver finds the owners of errors by matching the top in-project
+// frame of their latest event against a CODEOWNERS file.
+type ownerResolver struct {
+	c         *client.Client
+	projectID string
+	rules     *codeowners.File
+	locator   source.Locator
+}
+
+// addOwnerFlags registers the flags read by newOwnerResolver.
+func addOwnerFlags(cmd *cobra.Command) {
+	cmd.Flags().String("repo", ".", "Local checkout that frame paths are resolved against")
+	cmd.Flags().String("codeowners", "", "CODEOWNERS file (default: looked up in the repository)")
+	cmd.Flags().StringSlice("path-prefix", nil, "Rewrite frame path prefixes, as FROM=TO (e.g. /app/=./)")
+}
+
+func newOwnerResolver(cmd *cobra.Command, c *client.Client, projectID string) (*ownerResolver, error) {
+	repo, _ := cmd.Flags().GetString("repo")
+	locator, err := locatorFromFlags(cmd, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules *codeowners.File
+	if file, _ := cmd.Flags().GetString("codeowners"); file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if rules, err = codeowners.Parse(f); err != nil {
+			return nil, err
+		}
+	} else if rules, _, err = codeowners.Load(repo); err != nil {
+		return nil, err
+	}
+
+	return &ownerResolver{c: c, projectID: projectID, rules: rules, locator: locator}, nil
+}
+
+// resolve returns the ownership of e. Failing to fetch or read the latest
+// event is reported in the result; only a canceled context is an error.
+func (r *ownerResolver) resolve(ctx context.Context, e models.BugsnagError) (models.ErrorOwnership, error) {
+	o := models.ErrorOwnership{ErrorID: e.ID, ErrorClass: e.ErrorClass, Owners: []string{}}
+
+	event, err := r.c.GetLatestEvent(ctx, r.projectID, e.ID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return o, err
+		}
+		o.Error = err.Error()
+		return o, nil
+	}
+	exceptions, err := event.ParseExceptions()
+	if err != nil {
+		o.Error = "decoding exceptions: " + err.Error()
+		return o, nil
+	}
+
+	for _, ex := range exceptions {
+		for _, f := range ex.Stacktrace {
+			if f.InProject && f.File != "" {
+				o.File = f.File
+				break
+			}
+		}
+		if o.File != "" {
+			break
+		}
+	}
+	if o.File == "" {
+		o.Error = "no in-project frame"
+		return o, nil
+	}
+
+	if p, ok := r.locator.Locate(o.File); ok {
+		o.Path = filepath.ToSlash(p)
+	} else {
+		o.Path = strings.TrimLeft(path.Clean("/"+r.locator.Rewrite(o.File)), "/")
+	}
+
+	owners, rules := r.rules.Match(o.Path)
+	if owners != nil {
+		o.Owners = owners
+	}
+	for _, rule := range rules {
+		o.Rules = append(o.Rules, rule.Pattern)
+	}
+	return o, nil
+}
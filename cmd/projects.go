@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
 var projectsCmd = &cobra.Command{
@@ -27,18 +25,10 @@ var projectsListCmd = &cobra.Command{
 			return fmt.Errorf("--org-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		projects, hasMore, err := c.ListProjects(orgID, getAllPages())
-		if err != nil {
-			return err
-		}
-
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(projects), len(projects), hasMore)
-		}
-		return p.PrintList(projects, len(projects), hasMore)
+		return streamList(cmd.Context(), p, c.ProjectsPager(orgID, getAllPages()))
 	},
 }
 
@@ -56,10 +46,10 @@ var projectsGetCmd = &cobra.Command{
 			return fmt.Errorf("--project-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		project, err := c.GetProject(projectID)
+		project, err := c.GetProject(cmd.Context(), projectID)
 		if err != nil {
 			return err
 		}
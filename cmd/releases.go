@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
@@ -15,7 +20,70 @@ var releasesCmd = &cobra.Command{
 
 var releasesListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List releases for a project",
+	Short: "List releases for a project or release group",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		groupID, _ := cmd.Flags().GetString("release-group-id")
+		if projectID == "" && groupID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		stage, _ := cmd.Flags().GetString("release-stage")
+		sort, _ := cmd.Flags().GetString("sort")
+		if groupID != "" && (stage != "" || sort != "") {
+			return fmt.Errorf("--release-stage and --sort cannot be used with --release-group-id")
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+
+		return streamList(cmd.Context(), p, c.ReleasesPager(client.ListReleasesOptions{
+			ProjectID:      projectID,
+			ReleaseGroupID: groupID,
+			ReleaseStage:   stage,
+			Sort:           sort,
+			AllPages:       getAllPages(),
+		}))
+	},
+}
+
+var releasesGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a release by ID",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		releaseID, _ := cmd.Flags().GetString("release-id")
+		if releaseID == "" {
+			return fmt.Errorf("--release-id is required")
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+
+		release, err := c.GetRelease(cmd.Context(), releaseID)
+		if err != nil {
+			return err
+		}
+
+		return p.PrintSingle(release)
+	},
+}
+
+var releasesGroupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "List release groups for a project",
+	Long: `List release groups for a project. A release group gathers the releases
+of one app version in one release stage; list them with
+releases list --release-group-id.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		token, err := getAPIToken()
 		if err != nil {
@@ -27,24 +95,395 @@ var releasesListCmd = &cobra.Command{
 			return fmt.Errorf("--project-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		stage, _ := cmd.Flags().GetString("release-stage")
+		if stage == "" {
+			return fmt.Errorf("--release-stage is required")
+		}
+
+		topOnly, _ := cmd.Flags().GetBool("top-only")
+
+		c := newClient(token)
+		p := newPrinter()
+
+		return streamList(cmd.Context(), p, c.ReleaseGroupsPager(client.ListReleaseGroupsOptions{
+			ProjectID:    projectID,
+			ReleaseStage: stage,
+			TopOnly:      topOnly,
+			AllPages:     getAllPages(),
+		}))
+	},
+}
 
-		releases, hasMore, err := c.ListReleases(projectID, getAllPages())
+var releasesCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare errors and stability between two versions",
+	Long: `Compare two versions of an app before promoting one:
+
+  new errors         seen in --to, not in --from, and first seen after --from was released
+  regressed errors   seen in --to, not in --from, but first seen before (they came back)
+  resolved errors    seen in --from but no longer in --to
+
+along with the sessions and crash rate (share of sessions with an unhandled
+error) of each version. Releases of the same version in several release
+stages are added up unless --release-stage is given.
+
+The table format prints the figures side by side followed by the errors;
+csv and tsv print the errors only.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
 		if err != nil {
 			return err
 		}
 
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(releases), len(releases), hasMore)
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		from, _ := cmd.Flags().GetString("from")
+		if from == "" {
+			return fmt.Errorf("--from is required")
 		}
-		return p.PrintList(releases, len(releases), hasMore)
+
+		to, _ := cmd.Flags().GetString("to")
+		if to == "" {
+			return fmt.Errorf("--to is required")
+		}
+
+		stage, _ := cmd.Flags().GetString("release-stage")
+
+		c := newClient(token)
+		p := newPrinter()
+		ctx := cmd.Context()
+
+		releases, _, err := c.ListReleases(ctx, projectID, true)
+		if err != nil {
+			return err
+		}
+		fromSummary, fromTime, err := summarizeRelease(releases, from, stage)
+		if err != nil {
+			return err
+		}
+		toSummary, _, err := summarizeRelease(releases, to, stage)
+		if err != nil {
+			return err
+		}
+
+		fromErrors, err := versionErrors(ctx, c, projectID, from, stage)
+		if err != nil {
+			return err
+		}
+		toErrors, err := versionErrors(ctx, c, projectID, to, stage)
+		if err != nil {
+			return err
+		}
+
+		comparison := compareReleases(fromSummary, toSummary, fromTime, fromErrors, toErrors)
+
+		switch p.Format {
+		case "table", "pretty":
+			metrics := comparison.Metrics()
+			if err := p.PrintList(output.ToTableRenderers(metrics), len(metrics), false); err != nil {
+				return err
+			}
+			fmt.Fprintln(p.Out)
+			return printList(p, comparison.Changes())
+		case "csv", "tsv":
+			return printList(p, comparison.Changes())
+		}
+		return p.PrintSingle(comparison)
 	},
 }
 
+var releasesGateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "Check a release against stability thresholds",
+	Long: `Check a release against stability thresholds, to halt a rollout from a
+CI pipeline when it is unhealthy. Only the thresholds given are checked:
+
+  --min-crash-free   minimum percentage of sessions without an unhandled error
+  --max-new-errors   maximum number of errors first seen in this release
+  --min-sessions     minimum number of sessions, so that the rates mean something
+
+The verdict, with the outcome of each check, is printed either way. When a
+check fails the command exits with code 5, distinct from the codes used when
+the checks could not be run.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		version, _ := cmd.Flags().GetString("version")
+		if version == "" {
+			return fmt.Errorf("--version is required")
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("min-crash-free") && !flags.Changed("max-new-errors") && !flags.Changed("min-sessions") {
+			return fmt.Errorf("one of --min-crash-free, --max-new-errors or --min-sessions is required")
+		}
+
+		stage, _ := flags.GetString("release-stage")
+
+		c := newClient(token)
+		p := newPrinter()
+		ctx := cmd.Context()
+
+		releases, _, err := c.ListReleases(ctx, projectID, true)
+		if err != nil {
+			return err
+		}
+		summary, releaseTime, err := summarizeRelease(releases, version, stage)
+		if err != nil {
+			return err
+		}
+
+		gate := models.ReleaseGate{
+			Version:       version,
+			ReleaseStages: summary.ReleaseStages,
+			Passed:        true,
+			Checks:        []models.GateCheck{},
+			NewErrors:     []models.ReleaseErrorChange{},
+		}
+
+		if flags.Changed("min-sessions") {
+			minSessions, _ := flags.GetInt("min-sessions")
+			gate.Checks = append(gate.Checks, sessionsCheck(summary, minSessions))
+		}
+		if flags.Changed("min-crash-free") {
+			minCrashFree, _ := flags.GetFloat64("min-crash-free")
+			gate.Checks = append(gate.Checks, crashFreeCheck(summary, minCrashFree))
+		}
+		if flags.Changed("max-new-errors") {
+			maxNewErrors, _ := flags.GetInt("max-new-errors")
+			errs, err := versionErrors(ctx, c, projectID, version, stage)
+			if err != nil {
+				return err
+			}
+			for _, e := range introducedErrors(errs, releaseTime) {
+				gate.NewErrors = append(gate.NewErrors, errorChange("new", e))
+			}
+			gate.Checks = append(gate.Checks, newErrorsCheck(len(gate.NewErrors), maxNewErrors))
+		}
+
+		var failed []string
+		for _, check := range gate.Checks {
+			if !check.Passed {
+				gate.Passed = false
+				failed = append(failed, check.Name)
+			}
+		}
+
+		if p.Tabular() {
+			err = printList(p, gate.Checks)
+		} else {
+			err = p.PrintSingle(gate)
+		}
+		if err != nil {
+			return err
+		}
+		if !gate.Passed {
+			return &checkFailedError{msg: fmt.Sprintf("release %s failed the gate: %s", version, strings.Join(failed, ", "))}
+		}
+		return nil
+	},
+}
+
+func sessionsCheck(s models.ReleaseSummary, minSessions int) models.GateCheck {
+	actual := float64(s.TotalSessions)
+	check := models.GateCheck{Name: "min_sessions", Actual: &actual, Threshold: float64(minSessions)}
+	check.Passed = s.TotalSessions >= minSessions
+	check.Message = fmt.Sprintf("%d sessions, at least %d required", s.TotalSessions, minSessions)
+	return check
+}
+
+// crashFreeCheck fails a release without sessions: there is nothing to show
+// it is stable.
+func crashFreeCheck(s models.ReleaseSummary, minCrashFree float64) models.GateCheck {
+	check := models.GateCheck{Name: "min_crash_free", Actual: s.CrashFreeRate, Threshold: minCrashFree}
+	if s.CrashFreeRate == nil {
+		check.Message = "no sessions reported, crash-free rate unknown"
+		return check
+	}
+	check.Passed = *s.CrashFreeRate >= minCrashFree
+	check.Message = fmt.Sprintf("%.2f%% crash-free sessions, at least %g%% required", *s.CrashFreeRate, minCrashFree)
+	return check
+}
+
+func newErrorsCheck(count, maxNewErrors int) models.GateCheck {
+	actual := float64(count)
+	check := models.GateCheck{Name: "max_new_errors", Actual: &actual, Threshold: float64(maxNewErrors)}
+	check.Passed = count <= maxNewErrors
+	check.Message = fmt.Sprintf("%d new errors, at most %d allowed", count, maxNewErrors)
+	return check
+}
+
+// introducedErrors keeps the errors first seen, in any version, at or after
+// releaseTime. Without a release time every error counts as introduced.
+func introducedErrors(errs []models.BugsnagError, releaseTime time.Time) []models.BugsnagError {
+	var out []models.BugsnagError
+	for _, e := range errs {
+		firstSeen, err := time.Parse(time.RFC3339, errorFirstSeen(e))
+		if releaseTime.IsZero() || err != nil || !firstSeen.Before(releaseTime) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// summarizeRelease adds up the releases of version, limited to stage when it
+// is set, and returns the earliest of their release times.
+func summarizeRelease(releases []models.Release, version, stage string) (models.ReleaseSummary, time.Time, error) {
+	summary := models.ReleaseSummary{Version: version, ReleaseStages: []string{}}
+	var earliest time.Time
+	found := false
+	for _, r := range releases {
+		if r.Version != version || (stage != "" && r.ReleaseStage.Name != stage) {
+			continue
+		}
+		found = true
+		summary.TotalSessions += r.TotalSessionsCount
+		summary.UnhandledSessions += r.UnhandledSessionsCount
+		if r.ReleaseStage.Name != "" && !slices.Contains(summary.ReleaseStages, r.ReleaseStage.Name) {
+			summary.ReleaseStages = append(summary.ReleaseStages, r.ReleaseStage.Name)
+		}
+		if t, err := time.Parse(time.RFC3339, r.ReleaseTime); err == nil && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+			summary.ReleaseTime = r.ReleaseTime
+		}
+	}
+	if !found {
+		if stage != "" {
+			return summary, earliest, fmt.Errorf("release %s not found in release stage %s", version, stage)
+		}
+		return summary, earliest, fmt.Errorf("release %s not found", version)
+	}
+	summary.CrashRate, summary.CrashFreeRate = crashRates(summary.TotalSessions, summary.UnhandledSessions)
+	return summary, earliest, nil
+}
+
+// crashRates returns the percentages of sessions with and without an
+// unhandled error, or nil without sessions.
+func crashRates(total, unhandled int) (crash, crashFree *float64) {
+	if total <= 0 {
+		return nil, nil
+	}
+	rate := float64(unhandled) / float64(total) * 100
+	free := 100 - rate
+	return &rate, &free
+}
+
+// versionErrors lists every error seen in version, in stage when it is set.
+func versionErrors(ctx context.Context, c *client.Client, projectID, version, stage string) ([]models.BugsnagError, error) {
+	filters := []client.Filter{{Field: "app.version", Op: "eq", Value: version}}
+	if stage != "" {
+		filters = append(filters, client.Filter{Field: "app.release_stage", Op: "eq", Value: stage})
+	}
+	errs, _, err := c.ListErrors(ctx, client.ListErrorsOptions{ProjectID: projectID, Filters: filters, AllPages: true})
+	return errs, err
+}
+
+// compareReleases classifies the errors of two versions. An error seen only
+// in the newer version is new if it was first seen after the older version
+// was released, and regressed otherwise.
+func compareReleases(from, to models.ReleaseSummary, fromTime time.Time, fromErrors, toErrors []models.BugsnagError) models.ReleaseComparison {
+	from.ErrorsSeen = len(fromErrors)
+	to.ErrorsSeen = len(toErrors)
+	c := models.ReleaseComparison{
+		From:            from,
+		To:              to,
+		NewErrors:       []models.ReleaseErrorChange{},
+		RegressedErrors: []models.ReleaseErrorChange{},
+		ResolvedErrors:  []models.ReleaseErrorChange{},
+	}
+	if from.CrashRate != nil && to.CrashRate != nil {
+		change := *to.CrashRate - *from.CrashRate
+		c.CrashRateChange = &change
+	}
+
+	seenIn := func(errs []models.BugsnagError) map[string]bool {
+		ids := make(map[string]bool, len(errs))
+		for _, e := range errs {
+			ids[e.ID] = true
+		}
+		return ids
+	}
+	inFrom, inTo := seenIn(fromErrors), seenIn(toErrors)
+
+	for _, e := range toErrors {
+		if inFrom[e.ID] {
+			continue
+		}
+		if firstSeen, err := time.Parse(time.RFC3339, errorFirstSeen(e)); err == nil && !fromTime.IsZero() && firstSeen.Before(fromTime) {
+			c.RegressedErrors = append(c.RegressedErrors, errorChange("regressed", e))
+		} else {
+			c.NewErrors = append(c.NewErrors, errorChange("new", e))
+		}
+	}
+	for _, e := range fromErrors {
+		if !inTo[e.ID] {
+			c.ResolvedErrors = append(c.ResolvedErrors, errorChange("resolved", e))
+		}
+	}
+	return c
+}
+
+// errorFirstSeen returns when an error first occurred in any version: the
+// first_seen of a filtered list is the first occurrence matching the filter.
+func errorFirstSeen(e models.BugsnagError) string {
+	if e.FirstSeenUnfiltered != "" {
+		return e.FirstSeenUnfiltered
+	}
+	return e.FirstSeen
+}
+
+func errorChange(change string, e models.BugsnagError) models.ReleaseErrorChange {
+	return models.ReleaseErrorChange{
+		Change:     change,
+		ID:         e.ID,
+		ErrorClass: e.ErrorClass,
+		Message:    e.Message,
+		Severity:   e.Severity,
+		Events:     e.EventsCount,
+		FirstSeen:  errorFirstSeen(e),
+		URL:        e.URL,
+	}
+}
+
 func init() {
-	releasesListCmd.Flags().String("project-id", "", "Project ID (required)")
+	releasesListCmd.Flags().String("project-id", "", "Project ID (required unless --release-group-id is set)")
+	releasesListCmd.Flags().String("release-group-id", "", "List the releases of this release group instead")
+	releasesListCmd.Flags().String("release-stage", "", "Only list releases in this release stage")
+	releasesListCmd.Flags().String("sort", "", "Sort field (timestamp, percent_of_sessions)")
+
+	releasesGetCmd.Flags().String("release-id", "", "Release ID (required)")
+
+	releasesGroupsCmd.Flags().String("project-id", "", "Project ID (required)")
+	releasesGroupsCmd.Flags().String("release-stage", "production", "Release stage of the groups")
+	releasesGroupsCmd.Flags().Bool("top-only", false, "Only list top release groups")
+
+	releasesCompareCmd.Flags().String("project-id", "", "Project ID (required)")
+	releasesCompareCmd.Flags().String("from", "", "Version to compare from, e.g. 1.4.0 (required)")
+	releasesCompareCmd.Flags().String("to", "", "Version to compare to, e.g. 1.5.0 (required)")
+	releasesCompareCmd.Flags().String("release-stage", "", "Only compare releases and errors in this release stage")
+
+	releasesGateCmd.Flags().String("project-id", "", "Project ID (required)")
+	releasesGateCmd.Flags().String("version", "", "Version to check, e.g. 1.5.0 (required)")
+	releasesGateCmd.Flags().Float64("min-crash-free", 0, "Minimum crash-free sessions percentage, e.g. 99.5")
+	releasesGateCmd.Flags().Int("max-new-errors", 0, "Maximum number of errors introduced by the release")
+	releasesGateCmd.Flags().Int("min-sessions", 0, "Minimum number of sessions")
+	releasesGateCmd.Flags().String("release-stage", "", "Only check the release and errors in this release stage")
 
 	releasesCmd.AddCommand(releasesListCmd)
+	releasesCmd.AddCommand(releasesGetCmd)
+	releasesCmd.AddCommand(releasesGroupsCmd)
+	releasesCmd.AddCommand(releasesCompareCmd)
+	releasesCmd.AddCommand(releasesGateCmd)
 	rootCmd.AddCommand(releasesCmd)
 }
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -22,17 +28,37 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C cancels the command context so in-flight requests stop and
+	// partially fetched lists can still be printed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if viper.GetBool("verbose") {
+		logRetries(os.Stderr)
+	}
+	if err != nil {
 		p := output.NewPrinter(getFormat())
 		exitCode := classifyError(err)
 		p.PrintError(err.Error(), exitCode)
 	}
 }
 
+// checkFailedError is returned by commands that ran to completion but whose
+// checks did not pass, so that scripts can tell them from failures to run.
+type checkFailedError struct {
+	msg string
+}
+
+func (e *checkFailedError) Error() string { return e.msg }
+
 func classifyError(err error) int {
 	if err == nil {
 		return output.ExitOK
 	}
+	var checkErr *checkFailedError
+	if errors.As(err, &checkErr) {
+		return output.ExitCheckFailed
+	}
 	msg := err.Error()
 	if strings.Contains(msg, "API token is required") || strings.Contains(msg, "is required") {
 		return output.ExitConfig
@@ -52,18 +78,33 @@ func classifyError(err error) int {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		clients = nil
+	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.bugsnag-cli.yaml)")
 	rootCmd.PersistentFlags().StringP("api-token", "t", "", "Bugsnag API token")
-	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format: json or table")
+	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format: json, ndjson, csv, tsv, table, pretty, chart, template or jsonpath")
+	rootCmd.PersistentFlags().String("template", "", "Go template or JSONPath expression for the template and jsonpath formats")
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "Comma-separated JSON fields (dotted paths) for csv and tsv output")
 	rootCmd.PersistentFlags().Int("per-page", 30, "Number of results per page")
 	rootCmd.PersistentFlags().BoolP("all-pages", "a", false, "Fetch all pages of results")
 	rootCmd.PersistentFlags().String("base-url", "https://api.bugsnag.com", "Bugsnag API base URL")
+	rootCmd.PersistentFlags().Duration("timeout", client.DefaultTimeout, "Timeout for each API request (0 for none)")
+	rootCmd.PersistentFlags().Int("max-retries", client.DefaultMaxRetries, "Retries on rate limiting (429), gateway errors and timeouts")
+	rootCmd.PersistentFlags().Int("rate-limit", client.DefaultRequestsPerMinute, "Maximum API requests per minute (0 disables the limit)")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Log retries and other diagnostics to stderr")
 
 	_ = viper.BindPFlag("api_token", rootCmd.PersistentFlags().Lookup("api-token"))
 	_ = viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
+	_ = viper.BindPFlag("columns", rootCmd.PersistentFlags().Lookup("columns"))
+	_ = viper.BindPFlag("template", rootCmd.PersistentFlags().Lookup("template"))
 	_ = viper.BindPFlag("per_page", rootCmd.PersistentFlags().Lookup("per-page"))
 	_ = viper.BindPFlag("base_url", rootCmd.PersistentFlags().Lookup("base-url"))
+	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	_ = viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("rate_limit", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 }
 
 func initConfig() {
@@ -100,6 +141,17 @@ func getFormat() string {
 	return f
 }
 
+// newPrinter returns a printer for the configured format, columns and
+// template. --template overrides a template given inline in --format.
+func newPrinter() *output.Printer {
+	p := output.NewPrinter(getFormat())
+	p.Columns = viper.GetStringSlice("columns")
+	if tmpl := viper.GetString("template"); tmpl != "" {
+		p.Template = tmpl
+	}
+	return p
+}
+
 func getPerPage() int {
 	pp := viper.GetInt("per_page")
 	if pp <= 0 || pp > 100 {
@@ -120,3 +172,74 @@ func getBaseURL() string {
 	}
 	return u
 }
+
+func getMaxRetries() int {
+	n := viper.GetInt("max_retries")
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// clients holds the clients created by newClient during a --verbose command
+// run, so that their retries can be totaled once it ends.
+var clients []*client.Client
+
+// newClient returns an API client configured from the global flags.
+func newClient(token string) *client.Client {
+	c := client.New(getBaseURL(), token, getPerPage())
+	c.MaxRetries = getMaxRetries()
+	c.SetTimeout(viper.GetDuration("timeout"))
+	c.SetRateLimit(viper.GetInt("rate_limit"))
+	if viper.GetBool("verbose") {
+		c.Log = os.Stderr
+		clients = append(clients, c)
+	}
+	return c
+}
+
+// logRetries writes the total number of retries of the command run to w,
+// if there were any.
+func logRetries(w io.Writer) {
+	var n int64
+	for _, c := range clients {
+		n += c.Retries()
+	}
+	if n > 0 {
+		fmt.Fprintf(w, "retries: %d\n", n)
+	}
+}
+
+// printList prints an already fetched list in the configured format.
+func printList[T output.TableRenderer](p *output.Printer, items []T) error {
+	if p.Tabular() {
+		return p.PrintList(output.ToTableRenderers(items), len(items), false)
+	}
+	return p.PrintList(items, len(items), false)
+}
+
+// streamList prints items as the pager fetches them, so that --all-pages
+// output starts with the first page. If a page fails (including on Ctrl-C)
+// after some items were printed, the list is closed with has_more set so the
+// output stays valid, and the error is returned.
+func streamList[T any](ctx context.Context, p *output.Printer, pager *client.Pager[T]) error {
+	return streamSeq(p, pager.All(ctx), pager.HasMore)
+}
+
+// streamSeq prints the items of seq as they are yielded, closing the list
+// like streamList on failure. hasMore is called once seq is exhausted.
+func streamSeq[T any](p *output.Printer, seq iter.Seq2[T, error], hasMore func() bool) error {
+	lw := p.NewListWriter()
+	for item, err := range seq {
+		if err != nil {
+			if lw.Count() > 0 {
+				_ = lw.Close(true)
+			}
+			return err
+		}
+		if err := lw.Write(item); err != nil {
+			return err
+		}
+	}
+	return lw.Close(hasMore())
+}
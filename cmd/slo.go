@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+var sloCmd = &cobra.Command{
+	Use:   "slo",
+	Short: "Track crash-free session objectives",
+}
+
+var sloStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show error budget and burn rates of crash-free SLOs",
+	Long: `Show the error budget and burn rates of crash-free sessions objectives.
+
+The stability trend buckets within --window, ending at the most recent
+bucket, are added up. The error budget is the number of sessions with an
+unhandled error the target allows over those sessions; the output shows how
+much of it is consumed and remains. Burn rates compare the share of crashed
+sessions over --short-window and --long-window with the share the target
+allows: at 1 the budget runs out exactly at the end of the window. When both
+are above --burn-rate-threshold the SLO is "burning".
+
+Check one project with --project-id and --target, or several with --file:
+
+  slos:
+    - name: checkout-web
+      project_id: 5f8a...
+      release_stage: production
+      target: 99.8
+      window: 28d
+
+Entries without a target or window take them from the flags. The command
+exits with code 5 when an error budget is exhausted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		flags := cmd.Flags()
+		file, _ := flags.GetString("file")
+		target, _ := flags.GetFloat64("target")
+		window, _ := flags.GetString("window")
+
+		var defs []sloDefinition
+		if file != "" {
+			defs, err = loadSLODefinitions(file)
+			if err != nil {
+				return err
+			}
+		} else {
+			projectID, _ := flags.GetString("project-id")
+			if projectID == "" {
+				return fmt.Errorf("--project-id is required")
+			}
+			if !flags.Changed("target") {
+				return fmt.Errorf("--target is required")
+			}
+			stage, _ := flags.GetString("release-stage")
+			defs = []sloDefinition{{ProjectID: projectID, ReleaseStage: stage}}
+		}
+
+		var burnWindows []burnWindow
+		for _, name := range []string{"short-window", "long-window"} {
+			s, _ := flags.GetString(name)
+			d, err := parseDuration(s)
+			if err != nil {
+				return fmt.Errorf("--%s: %w", name, err)
+			}
+			burnWindows = append(burnWindows, burnWindow{label: s, d: d})
+		}
+		threshold, _ := flags.GetFloat64("burn-rate-threshold")
+
+		for i := range defs {
+			if defs[i].Target == 0 {
+				defs[i].Target = target
+			}
+			if defs[i].Window == "" {
+				defs[i].Window = window
+			}
+			if err := defs[i].validate(); err != nil {
+				return err
+			}
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+		ctx := cmd.Context()
+
+		statuses := make([]models.SLOStatus, 0, len(defs))
+		var exhausted, failed []string
+		for _, def := range defs {
+			trend, err := c.GetStabilityTrend(ctx, def.ProjectID, def.ReleaseStage)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var status models.SLOStatus
+			if err != nil {
+				status = models.SLOStatus{Target: def.Target, Window: def.Window, BurnRates: []models.BurnRate{}, Status: "error", Error: err.Error()}
+				failed = append(failed, def.name())
+			} else {
+				window, _ := parseDuration(def.Window)
+				status = sloStatus(trend.TimelinePoints, def.Target, window, burnWindows, threshold)
+				status.Window = def.Window
+			}
+			status.Name = def.name()
+			status.ProjectID = def.ProjectID
+			status.ReleaseStage = def.ReleaseStage
+			if status.Exhausted {
+				exhausted = append(exhausted, status.Name)
+			}
+			statuses = append(statuses, status)
+		}
+
+		if err := printList(p, statuses); err != nil {
+			return err
+		}
+		if len(exhausted) > 0 {
+			return &checkFailedError{msg: fmt.Sprintf("error budget exhausted: %s", strings.Join(exhausted, ", "))}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("could not check %d of %d SLOs: %s", len(failed), len(defs), strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+// sloDefinition is one objective, from the flags or a definitions file.
+type sloDefinition struct {
+	Name         string  `mapstructure:"name"`
+	ProjectID    string  `mapstructure:"project_id"`
+	ReleaseStage string  `mapstructure:"release_stage"`
+	Target       float64 `mapstructure:"target"`
+	Window       string  `mapstructure:"window"`
+}
+
+func (d sloDefinition) name() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	if d.ReleaseStage != "" {
+		return d.ProjectID + "/" + d.ReleaseStage
+	}
+	return d.ProjectID
+}
+
+func (d sloDefinition) validate() error {
+	if d.ProjectID == "" {
+		return fmt.Errorf("SLO %q: project_id is required", d.Name)
+	}
+	if d.Target <= 0 || d.Target >= 100 {
+		return fmt.Errorf("SLO %s: target must be between 0 and 100, got %g", d.name(), d.Target)
+	}
+	if _, err := parseDuration(d.Window); err != nil {
+		return fmt.Errorf("SLO %s: window: %w", d.name(), err)
+	}
+	return nil
+}
+
+// loadSLODefinitions reads the slos list of a YAML, JSON or TOML file.
+func loadSLODefinitions(path string) ([]sloDefinition, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading SLO definitions: %w", err)
+	}
+	var defs []sloDefinition
+	if err := v.UnmarshalKey("slos", &defs); err != nil {
+		return nil, fmt.Errorf("reading SLO definitions from %s: %w", path, err)
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no SLOs defined in %s: expected a top-level slos list", path)
+	}
+	return defs, nil
+}
+
+// burnWindow is an alerting window, labelled as given on the command line.
+type burnWindow struct {
+	label string
+	d     time.Duration
+}
+
+// sloStatus computes the error budget of a crash-free target over the
+// points within window of the most recent bucket end, and the burn rates
+// over each of burnWindows.
+func sloStatus(points []models.TimelinePoint, target float64, window time.Duration, burnWindows []burnWindow, threshold float64) models.SLOStatus {
+	var end time.Time
+	for _, pt := range points {
+		if t, err := time.Parse(time.RFC3339, pt.BucketEnd); err == nil && t.After(end) {
+			end = t
+		}
+	}
+	allowed := (100 - target) / 100
+	start := end.Add(-window)
+
+	status := models.SLOStatus{
+		Target:    target,
+		BurnRates: []models.BurnRate{},
+		Status:    "ok",
+	}
+	if !end.IsZero() {
+		status.WindowStart = start.Format(time.RFC3339)
+		status.WindowEnd = end.Format(time.RFC3339)
+	}
+
+	status.TotalSessions, status.UnhandledSessions = sessionsSince(points, start)
+	_, status.CrashFreeRate = crashRates(status.TotalSessions, status.UnhandledSessions)
+	status.ErrorBudget = roundRate(allowed * float64(status.TotalSessions))
+	if status.TotalSessions > 0 {
+		// A target too close to 100 leaves a budget that rounds to 0: any
+		// crash exhausts it, and none consumes nothing.
+		var consumed float64
+		switch {
+		case status.ErrorBudget > 0:
+			consumed = roundRate(float64(status.UnhandledSessions) / status.ErrorBudget * 100)
+		case status.UnhandledSessions > 0:
+			consumed = 100
+		}
+		remaining := roundRate(100 - consumed)
+		status.BudgetConsumed, status.BudgetRemaining = &consumed, &remaining
+		status.Exhausted = consumed >= 100
+	}
+
+	status.BurnAlert = len(burnWindows) > 0
+	for _, w := range burnWindows {
+		burn := models.BurnRate{Window: w.label}
+		if total, unhandled := sessionsSince(points, end.Add(-w.d)); total > 0 {
+			rate := roundRate(float64(unhandled) / float64(total) / allowed)
+			burn.Rate = &rate
+		}
+		if burn.Rate == nil || *burn.Rate <= threshold {
+			status.BurnAlert = false
+		}
+		status.BurnRates = append(status.BurnRates, burn)
+	}
+
+	switch {
+	case status.Exhausted:
+		status.Status = "exhausted"
+	case status.BurnAlert:
+		status.Status = "burning"
+	}
+	return status
+}
+
+// roundRate drops the floating point noise of targets such as 99.8, which
+// would otherwise leave a budget spent to the session at 99.99999...%.
+func roundRate(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+// sessionsSince adds up the sessions of the buckets starting at or after
+// start.
+func sessionsSince(points []models.TimelinePoint, start time.Time) (total, unhandled int) {
+	for _, pt := range points {
+		t, err := time.Parse(time.RFC3339, pt.BucketStart)
+		if err != nil || t.Before(start) {
+			continue
+		}
+		total += pt.TotalSessionsCount
+		unhandled += pt.UnhandledSessionsCount
+	}
+	return total, unhandled
+}
+
+func init() {
+	sloStatusCmd.Flags().String("project-id", "", "Project ID (required without --file)")
+	sloStatusCmd.Flags().String("release-stage", "", "Release stage (optional)")
+	sloStatusCmd.Flags().Float64("target", 0, "Crash-free sessions target percentage, e.g. 99.8 (required without --file)")
+	sloStatusCmd.Flags().String("window", "28d", "Rolling SLO window")
+	sloStatusCmd.Flags().String("short-window", "1d", "Short burn rate window")
+	sloStatusCmd.Flags().String("long-window", "7d", "Long burn rate window")
+	sloStatusCmd.Flags().Float64("burn-rate-threshold", 1, "Burn rate above which, on both windows, an SLO is burning")
+	sloStatusCmd.Flags().String("file", "", "YAML, JSON or TOML file with a list of SLOs under slos")
+
+	sloCmd.AddCommand(sloStatusCmd)
+	rootCmd.AddCommand(sloCmd)
+}
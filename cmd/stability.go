@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
 )
 
@@ -29,25 +32,144 @@ var stabilityTrendCmd = &cobra.Command{
 
 		releaseStage, _ := cmd.Flags().GetString("release-stage")
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		trend, err := c.GetStabilityTrend(projectID, releaseStage)
+		trend, err := c.GetStabilityTrend(cmd.Context(), projectID, releaseStage)
 		if err != nil {
 			return err
 		}
 
-		if getFormat() == "table" {
+		if p.Tabular() || p.Format == "chart" {
 			return p.PrintList(output.ToTableRenderers(trend.TimelinePoints), len(trend.TimelinePoints), false)
 		}
 		return p.PrintSingle(trend)
 	},
 }
 
+var stabilityReleasesCmd = &cobra.Command{
+	Use:   "releases",
+	Short: "Rank releases by crash-free rate",
+	Long: `List the crash-free session and user rates of each release, ranked from
+the most stable. Rates come from the session counts Bugsnag keeps per
+release; releases without sessions are listed last, unranked.
+
+With --target, releases whose crash-free session rate is below it (or
+unknown) are flagged. With --baseline, each release shows the difference
+between its crash-free session rate and the baseline version's in the same
+release stage.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		releaseStage, _ := cmd.Flags().GetString("release-stage")
+		target, _ := cmd.Flags().GetFloat64("target")
+		baseline, _ := cmd.Flags().GetString("baseline")
+
+		c := newClient(token)
+		p := newPrinter()
+
+		releases, _, err := c.ReleasesPager(client.ListReleasesOptions{
+			ProjectID:    projectID,
+			ReleaseStage: releaseStage,
+			AllPages:     true,
+		}).Collect(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rows, err := releaseStability(releases, target, baseline)
+		if err != nil {
+			return err
+		}
+		return printList(p, rows)
+	},
+}
+
+// releaseStability computes the crash-free rates of releases and ranks them
+// by crash-free session rate. A zero target flags nothing; an empty baseline
+// computes no deltas.
+func releaseStability(releases []models.Release, target float64, baseline string) ([]models.ReleaseStability, error) {
+	rows := make([]models.ReleaseStability, 0, len(releases))
+	for _, r := range releases {
+		row := models.ReleaseStability{
+			ReleaseID:          r.ID,
+			Version:            r.Version,
+			ReleaseStage:       r.ReleaseStage.Name,
+			ReleaseTime:        r.ReleaseTime,
+			TotalSessions:      r.TotalSessionsCount,
+			UnhandledSessions:  r.UnhandledSessionsCount,
+			UsersSeen:          r.AccumulativeDailyUsersSeen,
+			UsersWithUnhandled: r.AccumulativeDailyUsersWithUnhandled,
+		}
+		_, row.CrashFreeSessionRate = crashRates(row.TotalSessions, row.UnhandledSessions)
+		_, row.CrashFreeUserRate = crashRates(row.UsersSeen, row.UsersWithUnhandled)
+		row.BelowTarget = target > 0 && (row.CrashFreeSessionRate == nil || *row.CrashFreeSessionRate < target)
+		rows = append(rows, row)
+	}
+
+	if baseline != "" {
+		base := map[string]models.ReleaseSummary{}
+		for _, r := range releases {
+			if r.Version != baseline {
+				continue
+			}
+			s := base[r.ReleaseStage.Name]
+			s.TotalSessions += r.TotalSessionsCount
+			s.UnhandledSessions += r.UnhandledSessionsCount
+			base[r.ReleaseStage.Name] = s
+		}
+		if len(base) == 0 {
+			return nil, fmt.Errorf("baseline release %s not found", baseline)
+		}
+		for i, row := range rows {
+			s, ok := base[row.ReleaseStage]
+			if !ok {
+				continue
+			}
+			if _, baseRate := crashRates(s.TotalSessions, s.UnhandledSessions); baseRate != nil && row.CrashFreeSessionRate != nil {
+				delta := *row.CrashFreeSessionRate - *baseRate
+				rows[i].BaselineDelta = &delta
+			}
+		}
+	}
+
+	slices.SortStableFunc(rows, func(a, b models.ReleaseStability) int {
+		switch {
+		case a.CrashFreeSessionRate == nil && b.CrashFreeSessionRate == nil:
+			return 0
+		case a.CrashFreeSessionRate == nil:
+			return 1
+		case b.CrashFreeSessionRate == nil:
+			return -1
+		}
+		return cmp.Compare(*b.CrashFreeSessionRate, *a.CrashFreeSessionRate)
+	})
+	for i := range rows {
+		if rows[i].CrashFreeSessionRate != nil {
+			rows[i].Rank = i + 1
+		}
+	}
+	return rows, nil
+}
+
 func init() {
 	stabilityTrendCmd.Flags().String("project-id", "", "Project ID (required)")
 	stabilityTrendCmd.Flags().String("release-stage", "", "Release stage (optional)")
 
+	stabilityReleasesCmd.Flags().String("project-id", "", "Project ID (required)")
+	stabilityReleasesCmd.Flags().String("release-stage", "", "Only list releases in this release stage")
+	stabilityReleasesCmd.Flags().Float64("target", 0, "Flag releases with a crash-free session rate below this percentage, e.g. 99.5")
+	stabilityReleasesCmd.Flags().String("baseline", "", "Show each release's crash-free session rate change against this version")
+
 	stabilityCmd.AddCommand(stabilityTrendCmd)
+	stabilityCmd.AddCommand(stabilityReleasesCmd)
 	rootCmd.AddCommand(stabilityCmd)
 }
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/git"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+// suspectReleases returns the release an error first appeared in, taken as
+// the latest release made before firstSeen, and the release before it in
+// the same release stage. An empty stage considers every stage.
+func suspectReleases(releases []models.Release, firstSeen time.Time, stage string) (introduced, previous models.Release, err error) {
+	type timedRelease struct {
+		models.Release
+		at time.Time
+	}
+	var timed []timedRelease
+	for _, r := range releases {
+		at, err := time.Parse(time.RFC3339, r.ReleaseTime)
+		if err != nil || (stage != "" && r.ReleaseStage.Name != stage) {
+			continue
+		}
+		timed = append(timed, timedRelease{r, at})
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	i := sort.Search(len(timed), func(i int) bool { return timed[i].at.After(firstSeen) }) - 1
+	if i < 0 {
+		return introduced, previous, fmt.Errorf("no release found before the error was first seen at %s", firstSeen.Format(time.RFC3339))
+	}
+	introduced = timed[i].Release
+	for j := i - 1; j >= 0; j-- {
+		if timed[j].ReleaseStage.Name == introduced.ReleaseStage.Name {
+			return introduced, timed[j].Release, nil
+		}
+	}
+	return introduced, previous, fmt.Errorf("no release found before %s", introduced.Version)
+}
+
+// releaseRevision returns the source control revision of a release, which
+// must be a commit SHA or a ref name to be passed to git.
+func releaseRevision(r models.Release) (string, error) {
+	if r.SourceControl == nil || r.SourceControl.Revision == "" {
+		return "", fmt.Errorf("release %s has no source control revision", r.Version)
+	}
+	if err := git.CheckRevision(r.SourceControl.Revision); err != nil {
+		return "", fmt.Errorf("release %s: %w", r.Version, err)
+	}
+	return r.SourceControl.Revision, nil
+}
+
+// inProjectFiles lists the distinct files of the in-project frames, in the
+// order they first appear in the exceptions.
+func inProjectFiles(exceptions []models.Exception) []string {
+	var files []string
+	for _, ex := range exceptions {
+		for _, f := range ex.Stacktrace {
+			if f.InProject && f.File != "" && !slices.Contains(files, f.File) {
+				files = append(files, f.File)
+			}
+		}
+	}
+	return files
+}
+
+// rankSuspects keeps the commits that changed a frame file and scores them:
+// the nth frame file matched adds 1/n, so the top of the trace counts most.
+// Ties are broken by recency, as returned by git log.
+func rankSuspects(commits []git.LogEntry, frameFiles []string) []models.SuspectCommit {
+	suspects := []models.SuspectCommit{}
+	for _, c := range commits {
+		s := models.SuspectCommit{
+			SHA:          c.SHA,
+			Author:       c.Author,
+			Email:        c.Email,
+			Date:         c.Time.Format(time.DateOnly),
+			Subject:      c.Subject,
+			MatchedFiles: []string{},
+		}
+		for n, frame := range frameFiles {
+			for _, changed := range c.Files {
+				if framePathMatches(changed, frame) {
+					s.MatchedFiles = append(s.MatchedFiles, changed)
+					s.Score += 1 / float64(n+1)
+					break
+				}
+			}
+		}
+		if len(s.MatchedFiles) > 0 {
+			suspects = append(suspects, s)
+		}
+	}
+	sort.SliceStable(suspects, func(i, j int) bool { return suspects[i].Score > suspects[j].Score })
+	return suspects
+}
+
+// framePathMatches reports whether a repository path and a frame path name
+// the same file, allowing either to carry extra leading directories (a build
+// directory on the frame, or a module directory in the repository).
+func framePathMatches(repoPath, framePath string) bool {
+	repoPath = strings.TrimLeft(filepath.ToSlash(repoPath), "/")
+	framePath = strings.TrimLeft(path.Clean("/"+filepath.ToSlash(framePath)), "/")
+	return repoPath == framePath ||
+		strings.HasSuffix(framePath, "/"+repoPath) ||
+		strings.HasSuffix(repoPath, "/"+framePath)
+}
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/anomaly"
 	"github.com/yoanbernabeu/bugsnag-cli/internal/client"
-	"github.com/yoanbernabeu/bugsnag-cli/internal/output"
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
 var trendsCmd = &cobra.Command{
@@ -27,21 +31,21 @@ var trendsProjectCmd = &cobra.Command{
 			return fmt.Errorf("--project-id is required")
 		}
 
-		resolution, _ := cmd.Flags().GetString("resolution")
-		bucketsCount, _ := cmd.Flags().GetInt("buckets-count")
+		opts, err := trendOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		opts.ProjectID = projectID
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		c := newClient(token)
+		p := newPrinter()
 
-		buckets, err := c.GetProjectTrends(projectID, resolution, bucketsCount)
+		buckets, err := c.GetTrends(cmd.Context(), opts)
 		if err != nil {
 			return err
 		}
 
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(buckets), len(buckets), false)
-		}
-		return p.PrintList(buckets, len(buckets), false)
+		return printList(p, buckets)
 	},
 }
 
@@ -64,30 +68,277 @@ var trendsErrorCmd = &cobra.Command{
 			return fmt.Errorf("--error-id is required")
 		}
 
-		c := client.New(getBaseURL(), token, getPerPage())
-		p := output.NewPrinter(getFormat())
+		opts, err := trendOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		opts.ProjectID = projectID
+		opts.ErrorID = errorID
+
+		c := newClient(token)
+		p := newPrinter()
+
+		buckets, err := c.GetTrends(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		return printList(p, buckets)
+	},
+}
+
+var trendsAnomaliesCmd = &cobra.Command{
+	Use:   "anomalies",
+	Short: "Find spikes in project and error trends",
+	Long: `Find the trend buckets whose events count is well above the ones before
+them, for the project and for its most frequent open errors.
+
+Each bucket is compared with a baseline of the previous ones: with
+--method mad (the default), the median of the last --window buckets and
+their median absolute deviation; with --method ewma, an exponentially
+weighted moving average and deviation of every bucket before it, with a
+span of --window buckets so that recent buckets weigh the most. A bucket
+with at least --min-events events and more than --sensitivity deviations
+above the baseline is an anomaly. Drops are not reported.
+
+With --recent, only anomalies in the last buckets are reported, so that
+a scheduled job alerts on a spike once:
+
+  bugsnag trends anomalies --project-id ID --resolution 1h --recent 1
+
+The command exits with code 5 when an anomaly is found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := getAPIToken()
+		if err != nil {
+			return err
+		}
+
+		flags := cmd.Flags()
+		projectID, _ := flags.GetString("project-id")
+		if projectID == "" {
+			return fmt.Errorf("--project-id is required")
+		}
+
+		trendOpts, err := trendOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		trendOpts.ProjectID = projectID
+		errorsCount, _ := flags.GetInt("errors")
+		recent, _ := flags.GetInt("recent")
+		minEvents, _ := flags.GetInt("min-events")
+		opts := anomaly.Options{MinValue: float64(minEvents)}
+		opts.Method, _ = flags.GetString("method")
+		opts.Window, _ = flags.GetInt("window")
+		opts.Sensitivity, _ = flags.GetFloat64("sensitivity")
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+
+		c := newClient(token)
+		p := newPrinter()
+		ctx := cmd.Context()
 
-		buckets, err := c.GetErrorTrends(projectID, errorID)
+		buckets, err := c.GetTrends(ctx, trendOpts)
+		if err != nil {
+			return err
+		}
+		anomalies, err := trendAnomalies(buckets, opts, recent, models.TrendAnomaly{Scope: "project"})
 		if err != nil {
 			return err
 		}
 
-		if getFormat() == "table" {
-			return p.PrintList(output.ToTableRenderers(buckets), len(buckets), false)
+		if errorsCount > 0 {
+			errs := c.ErrorsPager(client.ListErrorsOptions{
+				ProjectID: projectID,
+				Status:    "open",
+				Sort:      "events",
+				Direction: "desc",
+				AllPages:  true,
+			})
+			checked := 0
+			for e, err := range errs.All(ctx) {
+				if err != nil {
+					return err
+				}
+				errorOpts := trendOpts
+				errorOpts.ErrorID = e.ID
+				buckets, err := c.GetTrends(ctx, errorOpts)
+				if err != nil {
+					return fmt.Errorf("getting the trend of error %s: %w", e.ID, err)
+				}
+				found, err := trendAnomalies(buckets, opts, recent, models.TrendAnomaly{
+					Scope:      "error",
+					ErrorID:    e.ID,
+					ErrorClass: e.ErrorClass,
+					Message:    e.Message,
+				})
+				if err != nil {
+					return err
+				}
+				anomalies = append(anomalies, found...)
+				if checked++; checked == errorsCount {
+					break
+				}
+			}
 		}
-		return p.PrintList(buckets, len(buckets), false)
+
+		if err := printList(p, anomalies); err != nil {
+			return err
+		}
+		if len(anomalies) > 0 {
+			return &checkFailedError{msg: fmt.Sprintf("anomalies found in %s", anomalyScopes(anomalies))}
+		}
+		return nil
 	},
 }
 
+// trendAnomalies returns the buckets detected as anomalies, among the last
+// recent ones when recent is positive, filled in from template.
+func trendAnomalies(buckets []models.TrendBucket, opts anomaly.Options, recent int, template models.TrendAnomaly) ([]models.TrendAnomaly, error) {
+	values := make([]float64, len(buckets))
+	for i, b := range buckets {
+		values[i] = float64(b.EventsCount)
+	}
+	points, err := anomaly.Detect(values, opts)
+	if err != nil {
+		return nil, err
+	}
+	anomalies := []models.TrendAnomaly{}
+	for _, pt := range points {
+		if recent > 0 && pt.Index < len(buckets)-recent {
+			continue
+		}
+		a := template
+		b := buckets[pt.Index]
+		a.From, a.To, a.EventsCount = b.From, b.To, b.EventsCount
+		a.Baseline = math.Round(pt.Baseline*100) / 100
+		a.Score = math.Round(pt.Score*100) / 100
+		anomalies = append(anomalies, a)
+	}
+	return anomalies, nil
+}
+
+// anomalyScopes lists the project and errors with anomalies, once each.
+func anomalyScopes(anomalies []models.TrendAnomaly) string {
+	var scopes []string
+	seen := map[string]bool{}
+	for _, a := range anomalies {
+		scope := "project"
+		if a.Scope == "error" {
+			scope = "error " + a.ErrorID
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// addTrendFlags registers the bucketing and time range flags shared by the
+// trends commands.
+func addTrendFlags(cmd *cobra.Command) {
+	cmd.Flags().String("resolution", "", "Time resolution of each bucket (1h, 1d, etc.)")
+	cmd.Flags().Int("buckets-count", 0, "Number of trend buckets")
+	cmd.Flags().String("since", "", "Only count events after this time (RFC3339 or relative, e.g. 24h, 7d)")
+	cmd.Flags().String("from", "", "Only count events after this RFC3339 time")
+	cmd.Flags().String("to", "", "Only count events before this RFC3339 time")
+}
+
+// trendOptionsFromFlags reads the flags registered by addTrendFlags. The
+// time range becomes event.since and event.before filters, and the
+// resolution must fit within it.
+func trendOptionsFromFlags(cmd *cobra.Command) (client.TrendOptions, error) {
+	flags := cmd.Flags()
+	var opts client.TrendOptions
+	opts.Resolution, _ = flags.GetString("resolution")
+	opts.BucketsCount, _ = flags.GetInt("buckets-count")
+	since, _ := flags.GetString("since")
+	from, _ := flags.GetString("from")
+	to, _ := flags.GetString("to")
+
+	var resolution time.Duration
+	if opts.Resolution != "" {
+		d, err := parseDuration(opts.Resolution)
+		if err != nil || d <= 0 {
+			return opts, fmt.Errorf("invalid --resolution %q: expected a duration such as 1h or 1d", opts.Resolution)
+		}
+		resolution = d
+	}
+	if opts.BucketsCount < 0 {
+		return opts, fmt.Errorf("--buckets-count must be positive, got %d", opts.BucketsCount)
+	}
+	if since != "" && from != "" {
+		return opts, fmt.Errorf("--since and --from cannot be used together")
+	}
+
+	ref := now()
+	var start, end time.Time
+	var err error
+	if since != "" {
+		if start, err = parseTime(since, ref); err != nil {
+			return opts, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if from != "" {
+		if start, err = time.Parse(time.RFC3339, from); err != nil {
+			return opts, fmt.Errorf("invalid --from %q: expected an RFC3339 time", from)
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse(time.RFC3339, to); err != nil {
+			return opts, fmt.Errorf("invalid --to %q: expected an RFC3339 time", to)
+		}
+	}
+	if start.IsZero() && end.IsZero() {
+		return opts, nil
+	}
+	if resolution > 0 && opts.BucketsCount > 0 {
+		return opts, fmt.Errorf("--resolution and --buckets-count cannot be used together with a time range")
+	}
+
+	if !start.IsZero() {
+		opts.Filters = append(opts.Filters, client.Filter{Field: "event.since", Op: "eq", Value: start.UTC().Format(time.RFC3339)})
+	}
+	if !end.IsZero() {
+		opts.Filters = append(opts.Filters, client.Filter{Field: "event.before", Op: "eq", Value: end.UTC().Format(time.RFC3339)})
+	}
+	// With only --to the range has no start, so any resolution fits in it.
+	if start.IsZero() {
+		return opts, nil
+	}
+	if end.IsZero() {
+		end = ref
+	}
+	if !start.Before(end) {
+		return opts, fmt.Errorf("the time range is empty: %s is not before %s", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	}
+	if span := end.Sub(start); resolution > span {
+		return opts, fmt.Errorf("--resolution %s is longer than the %s time range", opts.Resolution, span)
+	}
+	return opts, nil
+}
+
 func init() {
 	trendsProjectCmd.Flags().String("project-id", "", "Project ID (required)")
-	trendsProjectCmd.Flags().String("resolution", "", "Time resolution (1h, 1d, etc.)")
-	trendsProjectCmd.Flags().Int("buckets-count", 0, "Number of trend buckets")
+	addTrendFlags(trendsProjectCmd)
 
 	trendsErrorCmd.Flags().String("project-id", "", "Project ID (required)")
 	trendsErrorCmd.Flags().String("error-id", "", "Error ID (required)")
+	addTrendFlags(trendsErrorCmd)
+
+	trendsAnomaliesCmd.Flags().String("project-id", "", "Project ID (required)")
+	addTrendFlags(trendsAnomaliesCmd)
+	trendsAnomaliesCmd.Flags().Int("errors", 10, "Number of most frequent open errors whose trends are checked too (0 for the project only)")
+	trendsAnomaliesCmd.Flags().String("method", anomaly.MAD, "Baseline method (mad, ewma)")
+	trendsAnomaliesCmd.Flags().Int("window", 12, "Number of previous buckets of the mad baseline, or span of the ewma baseline")
+	trendsAnomaliesCmd.Flags().Float64("sensitivity", 3.5, "Number of deviations above the baseline at which a bucket is an anomaly")
+	trendsAnomaliesCmd.Flags().Int("min-events", 10, "Events count below which a bucket is never an anomaly")
+	trendsAnomaliesCmd.Flags().Int("recent", 0, "Only report anomalies in the last N buckets (0 for all)")
 
 	trendsCmd.AddCommand(trendsProjectCmd)
 	trendsCmd.AddCommand(trendsErrorCmd)
+	trendsCmd.AddCommand(trendsAnomaliesCmd)
 	rootCmd.AddCommand(trendsCmd)
 }
@@ -0,0 +1,155 @@
+// Package anomaly finds the values of a time series that rise well above
+// the ones before them, such as a burst of events in error trend buckets.
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Baseline methods.
+const (
+	// MAD compares each value with the median of the preceding window,
+	// scaled by the median absolute deviation. It ignores past spikes.
+	MAD = "mad"
+	// EWMA compares each value with an exponentially weighted moving
+	// average and standard deviation of every value before it, with a span
+	// of Window values so that recent values weigh the most: the latest
+	// weighs 2/(Window+1). It follows gradual changes more closely.
+	EWMA = "ewma"
+)
+
+// madScale turns a median absolute deviation into an estimate of the
+// standard deviation of normally distributed values.
+const madScale = 1.4826
+
+// minDeviation is the smallest deviation a score is computed with, so that a
+// flat history does not make any change, however small, an anomaly.
+const minDeviation = 1.0
+
+// Options configure Detect.
+type Options struct {
+	// Method is MAD (the default) or EWMA.
+	Method string
+	// Window is the number of preceding values the MAD baseline is
+	// computed from, and the span of the EWMA: its weight is 2/(Window+1).
+	Window int
+	// MinHistory is the number of preceding values needed before a value
+	// is scored. It defaults to 3.
+	MinHistory int
+	// Sensitivity is the number of deviations above the baseline at which
+	// a value is an anomaly.
+	Sensitivity float64
+	// MinValue is the value below which nothing is an anomaly, to leave out
+	// rises from 0 to a handful.
+	MinValue float64
+}
+
+// Point is an anomalous value of the series.
+type Point struct {
+	Index    int
+	Value    float64
+	Baseline float64
+	// Deviation is the spread of the values the baseline was computed
+	// from, at least 1.
+	Deviation float64
+	// Score is the number of deviations the value is above the baseline.
+	Score float64
+}
+
+// Validate reports options that cannot be used.
+func (o Options) Validate() error {
+	switch o.Method {
+	case "", MAD, EWMA:
+	default:
+		return fmt.Errorf("unknown method %q: expected %s or %s", o.Method, MAD, EWMA)
+	}
+	if o.Window < 2 {
+		return fmt.Errorf("window must be at least 2, got %d", o.Window)
+	}
+	if o.Sensitivity <= 0 {
+		return fmt.Errorf("sensitivity must be positive, got %g", o.Sensitivity)
+	}
+	return nil
+}
+
+// Detect returns the values of the series that are anomalies, in order.
+// Only values with enough history before them are considered.
+func Detect(values []float64, opts Options) ([]Point, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.MinHistory <= 0 {
+		opts.MinHistory = 3
+	}
+	var points []Point
+	switch opts.Method {
+	case "", MAD:
+		points = scoreMAD(values, opts)
+	case EWMA:
+		points = scoreEWMA(values, opts)
+	}
+	var anomalies []Point
+	for _, pt := range points {
+		pt.Score = (pt.Value - pt.Baseline) / pt.Deviation
+		if pt.Score > opts.Sensitivity && pt.Value >= opts.MinValue {
+			anomalies = append(anomalies, pt)
+		}
+	}
+	return anomalies, nil
+}
+
+func scoreMAD(values []float64, opts Options) []Point {
+	var points []Point
+	for i := opts.MinHistory; i < len(values); i++ {
+		history := values[max(i-opts.Window, 0):i]
+		m := median(history)
+		deviations := make([]float64, len(history))
+		for j, v := range history {
+			deviations[j] = math.Abs(v - m)
+		}
+		points = append(points, Point{
+			Index:     i,
+			Value:     values[i],
+			Baseline:  m,
+			Deviation: max(madScale*median(deviations), minDeviation),
+		})
+	}
+	return points
+}
+
+func scoreEWMA(values []float64, opts Options) []Point {
+	if len(values) == 0 {
+		return nil
+	}
+	alpha := 2 / float64(opts.Window+1)
+	mean, variance := values[0], 0.0
+	var points []Point
+	for i := 1; i < len(values); i++ {
+		v := values[i]
+		if i >= opts.MinHistory {
+			points = append(points, Point{
+				Index:     i,
+				Value:     v,
+				Baseline:  mean,
+				Deviation: max(math.Sqrt(variance), minDeviation),
+			})
+		}
+		diff := v - mean
+		incr := alpha * diff
+		mean += incr
+		variance = (1 - alpha) * (variance + diff*incr)
+	}
+	return points
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
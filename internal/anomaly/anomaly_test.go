@@ -0,0 +1,157 @@
+package anomaly
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// hourly is a day of hourly event counts with a burst at index 15.
+var hourly = []float64{12, 9, 11, 14, 10, 12, 13, 8, 11, 12, 10, 13, 11, 9, 12, 96, 14, 11, 10, 12}
+
+func indexes(points []Point) []int {
+	var out []int
+	for _, pt := range points {
+		out = append(out, pt.Index)
+	}
+	return out
+}
+
+func TestDetect_MAD(t *testing.T) {
+	points, err := Detect(hourly, Options{Method: MAD, Window: 12, Sensitivity: 3.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(indexes(points), []int{15}) {
+		t.Fatalf("expected an anomaly at 15, got %+v", points)
+	}
+	pt := points[0]
+	if pt.Value != 96 || pt.Baseline != 11.5 {
+		t.Errorf("unexpected value or baseline: %+v", pt)
+	}
+	// MAD of the 12 values before is 1.5.
+	if math.Abs(pt.Deviation-1.5*madScale) > 1e-9 {
+		t.Errorf("expected deviation %g, got %g", 1.5*madScale, pt.Deviation)
+	}
+	if pt.Score < 30 {
+		t.Errorf("expected a high score, got %g", pt.Score)
+	}
+}
+
+func TestDetect_MADIgnoresPastSpikes(t *testing.T) {
+	// A second, smaller burst right after the first is still found: the
+	// median is not dragged up by the first one.
+	values := append([]float64{}, hourly[:17]...)
+	values[16] = 60
+	points, err := Detect(values, Options{Window: 12, Sensitivity: 3.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(indexes(points), []int{15, 16}) {
+		t.Errorf("expected anomalies at 15 and 16, got %+v", points)
+	}
+}
+
+func TestDetect_EWMA(t *testing.T) {
+	points, err := Detect(hourly, Options{Method: EWMA, Window: 6, Sensitivity: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(indexes(points), []int{15}) {
+		t.Fatalf("expected an anomaly at 15, got %+v", points)
+	}
+	if b := points[0].Baseline; b < 10 || b > 12 {
+		t.Errorf("expected a baseline around 11, got %g", b)
+	}
+}
+
+func TestDetect_EWMAFollowsGradualGrowth(t *testing.T) {
+	var values []float64
+	for i := range 30 {
+		values = append(values, float64(100+10*i))
+	}
+	points, err := Detect(values, Options{Method: EWMA, Window: 4, Sensitivity: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 0 {
+		t.Errorf("expected steady growth not to be an anomaly, got %+v", points)
+	}
+}
+
+func TestDetect_FlatHistory(t *testing.T) {
+	// Without any spread, the deviation is 1: 8 is not an anomaly at
+	// sensitivity 3.5, 14 is.
+	values := []float64{5, 5, 5, 5, 8, 5, 14}
+	points, err := Detect(values, Options{Window: 4, Sensitivity: 3.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(indexes(points), []int{6}) {
+		t.Errorf("expected an anomaly at 6, got %+v", points)
+	}
+	if points[0].Deviation != minDeviation {
+		t.Errorf("expected the minimum deviation, got %g", points[0].Deviation)
+	}
+}
+
+func TestDetect_MinValue(t *testing.T) {
+	values := []float64{0, 0, 0, 0, 0, 6}
+	points, _ := Detect(values, Options{Window: 4, Sensitivity: 3})
+	if len(points) != 1 {
+		t.Fatalf("expected the rise to 6 to be an anomaly, got %+v", points)
+	}
+	points, _ = Detect(values, Options{Window: 4, Sensitivity: 3, MinValue: 10})
+	if len(points) != 0 {
+		t.Errorf("expected no anomaly under the minimum value, got %+v", points)
+	}
+}
+
+func TestDetect_DropsAreNotAnomalies(t *testing.T) {
+	values := []float64{100, 98, 103, 101, 99, 0}
+	points, _ := Detect(values, Options{Window: 5, Sensitivity: 3})
+	if len(points) != 0 {
+		t.Errorf("expected no anomaly, got %+v", points)
+	}
+}
+
+func TestDetect_MinHistory(t *testing.T) {
+	values := []float64{1, 1, 50, 1, 1}
+	points, _ := Detect(values, Options{Window: 4, Sensitivity: 3})
+	if len(points) != 0 {
+		t.Errorf("expected the early value not to be scored, got %+v", points)
+	}
+	points, _ = Detect(values, Options{Window: 4, Sensitivity: 3, MinHistory: 2})
+	if !slices.Equal(indexes(points), []int{2}) {
+		t.Errorf("expected an anomaly at 2, got %+v", points)
+	}
+}
+
+func TestDetect_ShortSeries(t *testing.T) {
+	for _, method := range []string{MAD, EWMA} {
+		for _, values := range [][]float64{nil, {4}, {4, 9, 2}} {
+			points, err := Detect(values, Options{Method: method, Window: 4, Sensitivity: 3})
+			if err != nil || len(points) != 0 {
+				t.Errorf("%s %v: expected nothing, got %+v, %v", method, values, points, err)
+			}
+		}
+	}
+}
+
+func TestDetect_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		opts Options
+		want string
+	}{
+		{Options{Method: "zscore", Window: 4, Sensitivity: 3}, `unknown method "zscore"`},
+		{Options{Window: 1, Sensitivity: 3}, "window must be at least 2"},
+		{Options{Window: 4}, "sensitivity must be positive"},
+	}
+	for _, tt := range tests {
+		_, err := Detect(hourly, tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: expected error containing %q, got %v", tt.opts, tt.want, err)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,7 +15,14 @@ type Client struct {
 	BaseURL    string
 	Token      string
 	PerPage    int
+	MaxRetries int
 	HTTPClient *http.Client
+	// Log receives one line per retried or throttled request when set.
+	Log io.Writer
+
+	retries atomic.Int64
+	sleep   func(context.Context, time.Duration) error
+	limiter *rateLimiter
 }
 
 type APIError struct {
@@ -29,18 +38,41 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("API error (%d)", e.StatusCode)
 }
 
+// DefaultTimeout bounds each HTTP request unless overridden with SetTimeout.
+const DefaultTimeout = 30 * time.Second
+
 func New(baseURL, token string, perPage int) *Client {
 	return &Client{
-		BaseURL: baseURL,
-		Token:   token,
-		PerPage: perPage,
+		BaseURL:    baseURL,
+		Token:      token,
+		PerPage:    perPage,
+		MaxRetries: DefaultMaxRetries,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		sleep:   sleepContext,
+		limiter: newRateLimiter(DefaultRequestsPerMinute),
 	}
 }
 
-func (c *Client) newRequest(method, path string, params url.Values) (*http.Request, error) {
+// Retries returns the number of retries performed over the client's lifetime.
+func (c *Client) Retries() int64 {
+	return c.retries.Load()
+}
+
+// SetTimeout sets the deadline applied to each HTTP request. A value <= 0
+// removes the per-request deadline; the caller's context still applies.
+func (c *Client) SetTimeout(d time.Duration) {
+	c.HTTPClient.Timeout = max(d, 0)
+}
+
+// SetRateLimit replaces the client-side rate limit with perMinute requests
+// per minute. A value <= 0 disables limiting.
+func (c *Client) SetRateLimit(perMinute int) {
+	c.limiter = newRateLimiter(perMinute)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, params url.Values) (*http.Request, error) {
 	u, err := url.Parse(c.BaseURL + path)
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
@@ -52,9 +84,13 @@ func (c *Client) newRequest(method, path string, params url.Values) (*http.Reque
 	if _, ok := params["per_page"]; !ok && c.PerPage > 0 {
 		params.Set("per_page", fmt.Sprintf("%d", c.PerPage))
 	}
-	u.RawQuery = params.Encode()
+	if u.RawQuery != "" && len(params) > 0 {
+		u.RawQuery += "&" + params.Encode()
+	} else if len(params) > 0 {
+		u.RawQuery = params.Encode()
+	}
 
-	req, err := http.NewRequest(method, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -66,13 +102,13 @@ func (c *Client) newRequest(method, path string, params url.Values) (*http.Reque
 	return req, nil
 }
 
-func (c *Client) newRequestWithBody(method, path string, body io.Reader) (*http.Request, error) {
+func (c *Client) newRequestWithBody(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	u, err := url.Parse(c.BaseURL + path)
 	if err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -84,31 +120,26 @@ func (c *Client) newRequestWithBody(method, path string, body io.Reader) (*http.
 	return req, nil
 }
 
-func (c *Client) do(req *http.Request, v any) (*http.Response, error) {
-	resp, err := c.HTTPClient.Do(req)
+// newPageRequest builds a GET request for an absolute URL taken from a Link
+// header.
+func (c *Client) newPageRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		apiErr := &APIError{StatusCode: resp.StatusCode}
+	req.Header.Set("Authorization", "token "+c.Token)
+	req.Header.Set("Accept", "application/json")
 
-		var errResp struct {
-			Errors []map[string]string `json:"errors"`
-		}
-		if json.Unmarshal(body, &errResp) == nil && len(errResp.Errors) > 0 {
-			if msg, ok := errResp.Errors[0]["message"]; ok {
-				apiErr.Message = msg
-			}
-			apiErr.Errors = errResp.Errors
-		} else {
-			apiErr.Message = string(body)
-		}
+	return req, nil
+}
 
-		return resp, apiErr
+func (c *Client) do(req *http.Request, v any) (*http.Response, error) {
+	resp, err := c.send(req)
+	if err != nil {
+		return resp, err
 	}
+	defer resp.Body.Close()
 
 	if v != nil {
 		body, err := io.ReadAll(resp.Body)
@@ -122,3 +153,24 @@ func (c *Client) do(req *http.Request, v any) (*http.Response, error) {
 
 	return resp, nil
 }
+
+// parseAPIError builds an *APIError from a failed response, preferring the
+// message of the first entry in the API's "errors" array.
+func parseAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var errResp struct {
+		Errors []map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &errResp) == nil && len(errResp.Errors) > 0 {
+		if msg, ok := errResp.Errors[0]["message"]; ok {
+			apiErr.Message = msg
+		}
+		apiErr.Errors = errResp.Errors
+	} else {
+		apiErr.Message = string(body)
+	}
+
+	return apiErr
+}
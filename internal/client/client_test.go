@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -26,7 +27,7 @@ func TestNew(t *testing.T) {
 
 func TestNewRequest(t *testing.T) {
 	c := New("https://api.bugsnag.com", "test-token", 30)
-	req, err := c.newRequest("GET", "/user/organizations", nil)
+	req, err := c.newRequest(context.Background(), "GET", "/user/organizations", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -51,7 +52,7 @@ func TestNewRequestWithParams(t *testing.T) {
 		"status":   {"open"},
 		"severity": {"error"},
 	}
-	req, err := c.newRequest("GET", "/projects/123/errors", params)
+	req, err := c.newRequest(context.Background(), "GET", "/projects/123/errors", params)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -77,7 +78,7 @@ func TestDoSuccess(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	req, _ := c.newRequest("GET", "/test", nil)
+	req, _ := c.newRequest(context.Background(), "GET", "/test", nil)
 
 	var result map[string]string
 	_, err := c.do(req, &result)
@@ -99,7 +100,7 @@ func TestDoAPIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "bad-token", 30)
-	req, _ := c.newRequest("GET", "/test", nil)
+	req, _ := c.newRequest(context.Background(), "GET", "/test", nil)
 
 	var result map[string]string
 	_, err := c.do(req, &result)
@@ -127,7 +128,7 @@ func TestDoAPIErrorPlainText(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	req, _ := c.newRequest("GET", "/test", nil)
+	req, _ := c.newRequest(context.Background(), "GET", "/test", nil)
 
 	_, err := c.do(req, nil)
 	if err == nil {
@@ -146,7 +147,7 @@ func TestDoNilTarget(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	req, _ := c.newRequest("GET", "/test", nil)
+	req, _ := c.newRequest(context.Background(), "GET", "/test", nil)
 
 	_, err := c.do(req, nil)
 	if err != nil {
@@ -168,7 +169,7 @@ func TestAPIErrorString(t *testing.T) {
 
 func TestNewRequestInvalidURL(t *testing.T) {
 	c := New("://bad-url", "tok", 30)
-	_, err := c.newRequest("GET", "/test", nil)
+	_, err := c.newRequest(context.Background(), "GET", "/test", nil)
 	if err == nil {
 		t.Fatal("expected error for invalid URL")
 	}
@@ -176,7 +177,7 @@ func TestNewRequestInvalidURL(t *testing.T) {
 
 func TestNewRequestWithBodyInvalidURL(t *testing.T) {
 	c := New("://bad-url", "tok", 30)
-	_, err := c.newRequestWithBody("POST", "/test", nil)
+	_, err := c.newRequestWithBody(context.Background(), "POST", "/test", nil)
 	if err == nil {
 		t.Fatal("expected error for invalid URL")
 	}
@@ -190,7 +191,7 @@ func TestDoInvalidJSON(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "tok", 30)
-	req, _ := c.newRequest("GET", "/test", nil)
+	req, _ := c.newRequest(context.Background(), "GET", "/test", nil)
 
 	var result map[string]string
 	_, err := c.do(req, &result)
@@ -205,7 +206,7 @@ func TestDoInvalidJSON(t *testing.T) {
 func TestNewRequestPerPageNotOverridden(t *testing.T) {
 	c := New("https://api.test.com", "tok", 30)
 	params := map[string][]string{"per_page": {"50"}}
-	req, err := c.newRequest("GET", "/test", params)
+	req, err := c.newRequest(context.Background(), "GET", "/test", params)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -224,7 +225,7 @@ func TestFetchPageAPIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "tok", 30)
-	_, _, err := FetchSinglePage[testItem](c, "/test", nil)
+	_, _, err := FetchSinglePage[testItem](context.Background(), c, "/test", nil)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -247,7 +248,7 @@ func TestFetchPageInvalidJSON(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "tok", 30)
-	_, _, err := FetchSinglePage[testItem](c, "/test", nil)
+	_, _, err := FetchSinglePage[testItem](context.Background(), c, "/test", nil)
 	if err == nil {
 		t.Fatal("expected decode error")
 	}
@@ -272,7 +273,7 @@ func TestCollectAllPagesErrorOnSecondPage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "tok", 30)
-	_, err := CollectAllPages[testItem](c, "/test", nil)
+	_, err := CollectAllPages[testItem](context.Background(), c, "/test", nil)
 	if err == nil {
 		t.Fatal("expected error on second page")
 	}
@@ -280,7 +281,7 @@ func TestCollectAllPagesErrorOnSecondPage(t *testing.T) {
 
 func TestFetchSinglePageBuildError(t *testing.T) {
 	c := New("://bad", "tok", 30)
-	_, _, err := FetchSinglePage[testItem](c, "/test", nil)
+	_, _, err := FetchSinglePage[testItem](context.Background(), c, "/test", nil)
 	if err == nil {
 		t.Fatal("expected error for bad URL")
 	}
@@ -288,7 +289,7 @@ func TestFetchSinglePageBuildError(t *testing.T) {
 
 func TestCollectAllPagesBuildError(t *testing.T) {
 	c := New("://bad", "tok", 30)
-	_, err := CollectAllPages[testItem](c, "/test", nil)
+	_, err := CollectAllPages[testItem](context.Background(), c, "/test", nil)
 	if err == nil {
 		t.Fatal("expected error for bad URL")
 	}
@@ -1,16 +1,17 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) ListCollaborators(orgID string, allPages bool) ([]models.Collaborator, bool, error) {
+func (c *Client) ListCollaborators(ctx context.Context, orgID string, allPages bool) ([]models.Collaborator, bool, error) {
+	return c.CollaboratorsPager(orgID, allPages).Collect(ctx)
+}
+
+func (c *Client) CollaboratorsPager(orgID string, allPages bool) *Pager[models.Collaborator] {
 	path := fmt.Sprintf("/organizations/%s/collaborators", orgID)
-	if allPages {
-		items, err := CollectAllPages[models.Collaborator](c, path, nil)
-		return items, false, err
-	}
-	return FetchSinglePage[models.Collaborator](c, path, nil)
+	return NewPager[models.Collaborator](c, path, nil, allPages)
 }
@@ -2,22 +2,23 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) ListComments(projectID, errorID string, allPages bool) ([]models.Comment, bool, error) {
+func (c *Client) ListComments(ctx context.Context, projectID, errorID string, allPages bool) ([]models.Comment, bool, error) {
+	return c.CommentsPager(projectID, errorID, allPages).Collect(ctx)
+}
+
+func (c *Client) CommentsPager(projectID, errorID string, allPages bool) *Pager[models.Comment] {
 	path := fmt.Sprintf("/projects/%s/errors/%s/comments", projectID, errorID)
-	if allPages {
-		items, err := CollectAllPages[models.Comment](c, path, nil)
-		return items, false, err
-	}
-	return FetchSinglePage[models.Comment](c, path, nil)
+	return NewPager[models.Comment](c, path, nil, allPages)
 }
 
-func (c *Client) CreateComment(projectID, errorID, message string) (*models.Comment, error) {
+func (c *Client) CreateComment(ctx context.Context, projectID, errorID, message string) (*models.Comment, error) {
 	path := fmt.Sprintf("/projects/%s/errors/%s/comments", projectID, errorID)
 	body := map[string]string{"message": message}
 	jsonBody, err := json.Marshal(body)
@@ -25,7 +26,7 @@ func (c *Client) CreateComment(projectID, errorID, message string) (*models.Comm
 		return nil, err
 	}
 
-	req, err := c.newRequestWithBody("POST", path, bytes.NewReader(jsonBody))
+	req, err := c.newRequestWithBody(ctx, "POST", path, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
@@ -12,11 +16,16 @@ type ListErrorsOptions struct {
 	Severity  string
 	Sort      string
 	Direction string
+	Filters   []Filter
 	AllPages  bool
 }
 
-func (c *Client) ListErrors(opts ListErrorsOptions) ([]models.BugsnagError, bool, error) {
-	path := fmt.Sprintf("/projects/%s/errors", opts.ProjectID)
+func (c *Client) ListErrors(ctx context.Context, opts ListErrorsOptions) ([]models.BugsnagError, bool, error) {
+	return c.ErrorsPager(opts).Collect(ctx)
+}
+
+func (c *Client) ErrorsPager(opts ListErrorsOptions) *Pager[models.BugsnagError] {
+	path := withFilters(fmt.Sprintf("/projects/%s/errors", opts.ProjectID), opts.Filters)
 	params := map[string]string{}
 	if opts.Status != "" {
 		params["status"] = opts.Status
@@ -31,16 +40,59 @@ func (c *Client) ListErrors(opts ListErrorsOptions) ([]models.BugsnagError, bool
 		params["direction"] = opts.Direction
 	}
 
-	if opts.AllPages {
-		items, err := CollectAllPages[models.BugsnagError](c, path, params)
-		return items, false, err
+	return NewPager[models.BugsnagError](c, path, params, opts.AllPages)
+}
+
+func (c *Client) GetError(ctx context.Context, projectID, errorID string) (*models.BugsnagError, error) {
+	path := fmt.Sprintf("/projects/%s/errors/%s", projectID, errorID)
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var bugsnagErr models.BugsnagError
+	_, err = c.do(req, &bugsnagErr)
+	if err != nil {
+		return nil, err
 	}
-	return FetchSinglePage[models.BugsnagError](c, path, params)
+	return &bugsnagErr, nil
+}
+
+// ErrorUpdate describes a single operation applied to an error through the
+// PATCH endpoint. Only the fields relevant to Operation are sent.
+type ErrorUpdate struct {
+	Operation      string
+	Severity       string
+	CollaboratorID string
+	ReopenRules    *models.ReopenRules
 }
 
-func (c *Client) GetError(projectID, errorID string) (*models.BugsnagError, error) {
+func (u ErrorUpdate) body() map[string]any {
+	body := map[string]any{"operation": u.Operation}
+	switch u.Operation {
+	case "override_severity":
+		body["severity"] = u.Severity
+	case "assign":
+		body["assigned_collaborator_id"] = u.CollaboratorID
+	case "unassign":
+		body["operation"] = "assign"
+		body["assigned_collaborator_id"] = nil
+	case "snooze":
+		if u.ReopenRules != nil {
+			body["reopen_rules"] = u.ReopenRules
+		}
+	}
+	return body
+}
+
+func (c *Client) UpdateError(ctx context.Context, projectID, errorID string, update ErrorUpdate) (*models.BugsnagError, error) {
 	path := fmt.Sprintf("/projects/%s/errors/%s", projectID, errorID)
-	req, err := c.newRequest("GET", path, nil)
+	jsonBody, err := json.Marshal(update.body())
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequestWithBody(ctx, "PATCH", path, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +104,22 @@ func (c *Client) GetError(projectID, errorID string) (*models.BugsnagError, erro
 	}
 	return &bugsnagErr, nil
 }
+
+// BulkUpdateErrors applies update to every error in errorIDs with a single
+// request to the bulk endpoint.
+func (c *Client) BulkUpdateErrors(ctx context.Context, projectID string, errorIDs []string, update ErrorUpdate) error {
+	query := url.Values{"error_ids[]": errorIDs}
+	path := fmt.Sprintf("/projects/%s/errors?%s", projectID, query.Encode())
+	jsonBody, err := json.Marshal(update.body())
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newRequestWithBody(ctx, "PATCH", path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(req, nil)
+	return err
+}
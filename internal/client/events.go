@@ -1,29 +1,54 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) ListEvents(projectID, errorID string, allPages bool) ([]models.Event, bool, error) {
+type ListEventsOptions struct {
+	ProjectID string
+	ErrorID   string
+	Filters   []Filter
+	AllPages  bool
+}
+
+func (c *Client) ListEvents(ctx context.Context, opts ListEventsOptions) ([]models.Event, bool, error) {
+	return c.EventsPager(opts).Collect(ctx)
+}
+
+func (c *Client) EventsPager(opts ListEventsOptions) *Pager[models.Event] {
 	var path string
-	if errorID != "" {
-		path = fmt.Sprintf("/projects/%s/errors/%s/events", projectID, errorID)
+	if opts.ErrorID != "" {
+		path = fmt.Sprintf("/projects/%s/errors/%s/events", opts.ProjectID, opts.ErrorID)
 	} else {
-		path = fmt.Sprintf("/projects/%s/events", projectID)
+		path = fmt.Sprintf("/projects/%s/events", opts.ProjectID)
 	}
+	path = withFilters(path, opts.Filters)
 
-	if allPages {
-		items, err := CollectAllPages[models.Event](c, path, nil)
-		return items, false, err
-	}
-	return FetchSinglePage[models.Event](c, path, nil)
+	return NewPager[models.Event](c, path, nil, opts.AllPages)
 }
 
-func (c *Client) GetEvent(projectID, eventID string) (*models.Event, error) {
+func (c *Client) GetEvent(ctx context.Context, projectID, eventID string) (*models.Event, error) {
 	path := fmt.Sprintf("/projects/%s/events/%s", projectID, eventID)
-	req, err := c.newRequest("GET", path, nil)
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	_, err = c.do(req, &event)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetLatestEvent returns the most recent event of an error.
+func (c *Client) GetLatestEvent(ctx context.Context, projectID, errorID string) (*models.Event, error) {
+	path := fmt.Sprintf("/projects/%s/errors/%s/latest_event", projectID, errorID)
+	req, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
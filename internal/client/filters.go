@@ -0,0 +1,56 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Filter is a single Bugsnag filter, encoded on the query string as
+// filters[<Field>][][type]=<Op>&filters[<Field>][][value]=<Value>.
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// filterOps lists the comparison types accepted by the Data Access API.
+var filterOps = map[string]bool{"eq": true, "ne": true, "empty": true}
+
+// ParseFilter parses a "field:op:value" expression. The value may itself
+// contain colons (timestamps, URLs); only the first two separate fields.
+func ParseFilter(s string) (Filter, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 3 || parts[0] == "" {
+		return Filter{}, fmt.Errorf("invalid filter %q (expected field:op:value)", s)
+	}
+	f := Filter{Field: parts[0], Op: parts[1], Value: parts[2]}
+	if !filterOps[f.Op] {
+		return Filter{}, fmt.Errorf("invalid filter operator %q in %q (expected eq, ne or empty)", f.Op, s)
+	}
+	return f, nil
+}
+
+// encodeFilters renders filters as a query string. Pairs are written in
+// order rather than through url.Values, whose sorted encoding would separate
+// each type from its value when a field is filtered more than once.
+func encodeFilters(filters []Filter) string {
+	var b strings.Builder
+	for _, f := range filters {
+		key := "filters[" + f.Field + "][]"
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(key+"[type]") + "=" + url.QueryEscape(f.Op))
+		b.WriteString("&" + url.QueryEscape(key+"[value]") + "=" + url.QueryEscape(f.Value))
+	}
+	return b.String()
+}
+
+// withFilters appends the encoded filters to path as its query string.
+func withFilters(path string, filters []Filter) string {
+	if len(filters) == 0 {
+		return path
+	}
+	return path + "?" + encodeFilters(filters)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Filter
+	}{
+		{"app.release_stage:eq:production", Filter{Field: "app.release_stage", Op: "eq", Value: "production"}},
+		{"user.email:ne:bob@example.com", Filter{Field: "user.email", Op: "ne", Value: "bob@example.com"}},
+		{"event.since:eq:2024-01-01T00:00:00Z", Filter{Field: "event.since", Op: "eq", Value: "2024-01-01T00:00:00Z"}},
+		{"error.assigned_to:empty:", Filter{Field: "error.assigned_to", Op: "empty", Value: ""}},
+	}
+	for _, tt := range tests {
+		got, err := ParseFilter(tt.in)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFilter(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "app.version", "app.version:eq", ":eq:1.0", "app.version:gt:1.0"} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Errorf("ParseFilter(%q) expected error", bad)
+		}
+	}
+}
+
+func TestEncodeFiltersPreservesPairs(t *testing.T) {
+	got := encodeFilters([]Filter{
+		{Field: "app.version", Op: "eq", Value: "1.0"},
+		{Field: "app.version", Op: "ne", Value: "2.0"},
+	})
+	want := "filters%5Bapp.version%5D%5B%5D%5Btype%5D=eq&filters%5Bapp.version%5D%5B%5D%5Bvalue%5D=1.0" +
+		"&filters%5Bapp.version%5D%5B%5D%5Btype%5D=ne&filters%5Bapp.version%5D%5B%5D%5Bvalue%5D=2.0"
+	if got != want {
+		t.Errorf("encodeFilters:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestNewRequestKeepsFilterQuery(t *testing.T) {
+	c := New("https://api.bugsnag.com", "tok", 30)
+	path := withFilters("/projects/p1/errors", []Filter{{Field: "search", Op: "eq", Value: "timeout"}})
+	req, err := c.newRequest(context.Background(), "GET", path, toURLValues(map[string]string{"status": "open"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q, _ := url.ParseQuery(req.URL.RawQuery)
+	if q.Get("filters[search][][value]") != "timeout" || q.Get("filters[search][][type]") != "eq" {
+		t.Errorf("filter missing from query: %s", req.URL.RawQuery)
+	}
+	if q.Get("status") != "open" || q.Get("per_page") != "30" {
+		t.Errorf("params missing from query: %s", req.URL.RawQuery)
+	}
+}
+
+func TestWithFiltersEmpty(t *testing.T) {
+	if got := withFilters("/projects/p1/events", nil); got != "/projects/p1/events" {
+		t.Errorf("expected path unchanged, got %s", got)
+	}
+}
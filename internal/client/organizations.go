@@ -1,11 +1,15 @@
 package client
 
-import "github.com/yoanbernabeu/bugsnag-cli/internal/models"
-
-func (c *Client) ListOrganizations(allPages bool) ([]models.Organization, bool, error) {
-	if allPages {
-		items, err := CollectAllPages[models.Organization](c, "/user/organizations", nil)
-		return items, false, err
-	}
-	return FetchSinglePage[models.Organization](c, "/user/organizations", nil)
+import (
+	"context"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+func (c *Client) ListOrganizations(ctx context.Context, allPages bool) ([]models.Organization, bool, error) {
+	return c.OrganizationsPager(allPages).Collect(ctx)
+}
+
+func (c *Client) OrganizationsPager(allPages bool) *Pager[models.Organization] {
+	return NewPager[models.Organization](c, "/user/organizations", nil, allPages)
 }
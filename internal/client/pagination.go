@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"regexp"
 )
@@ -25,30 +27,12 @@ func parseLinkHeader(header string) string {
 }
 
 func fetchPage[T any](c *Client, req *http.Request) (*PageResult[T], error) {
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		apiErr := &APIError{StatusCode: resp.StatusCode}
-
-		var errResp struct {
-			Errors []map[string]string `json:"errors"`
-		}
-		if json.Unmarshal(body, &errResp) == nil && len(errResp.Errors) > 0 {
-			if msg, ok := errResp.Errors[0]["message"]; ok {
-				apiErr.Message = msg
-			}
-			apiErr.Errors = errResp.Errors
-		} else {
-			apiErr.Message = string(body)
-		}
-		return nil, apiErr
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
@@ -68,49 +52,95 @@ func fetchPage[T any](c *Client, req *http.Request) (*PageResult[T], error) {
 	}, nil
 }
 
-func FetchSinglePage[T any](c *Client, path string, params map[string]string) ([]T, bool, error) {
-	req, err := c.newRequest("GET", path, toURLValues(params))
-	if err != nil {
-		return nil, false, err
-	}
-
-	result, err := fetchPage[T](c, req)
-	if err != nil {
-		return nil, false, err
-	}
+// Pager walks a paginated list endpoint, following Link headers page by
+// page so that callers can process items as they arrive instead of
+// buffering the whole list.
+type Pager[T any] struct {
+	c        *Client
+	path     string
+	params   map[string]string
+	allPages bool
+	hasMore  bool
+}
 
-	return result.Items, result.HasMore, nil
+// NewPager returns a pager for path. Unless allPages is set, iteration
+// stops after the first page.
+func NewPager[T any](c *Client, path string, params map[string]string, allPages bool) *Pager[T] {
+	return &Pager[T]{c: c, path: path, params: params, allPages: allPages}
 }
 
-func CollectAllPages[T any](c *Client, path string, params map[string]string) ([]T, error) {
-	var all []T
+// All yields every item in order, fetching the next page only once the
+// previous one has been consumed. A failure is yielded once as the final
+// element.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		p.hasMore = true
 
-	req, err := c.newRequest("GET", path, toURLValues(params))
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		result, err := fetchPage[T](c, req)
+		req, err := p.c.newRequest(ctx, "GET", p.path, toURLValues(p.params))
 		if err != nil {
-			return nil, err
+			yield(zero, err)
+			return
 		}
 
-		all = append(all, result.Items...)
+		for {
+			page, err := fetchPage[T](p.c, req)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || !p.allPages {
+				p.hasMore = page.HasMore
+				return
+			}
 
-		if !result.HasMore {
-			break
+			req, err = p.c.newPageRequest(ctx, page.NextURL)
+			if err != nil {
+				yield(zero, fmt.Errorf("building next page request: %w", err))
+				return
+			}
 		}
+	}
+}
+
+// HasMore reports whether items remain beyond those yielded by the last
+// call to All: either the API has another page that was not requested, or
+// iteration stopped early on an error or break.
+func (p *Pager[T]) HasMore() bool {
+	return p.hasMore
+}
 
-		req, err = http.NewRequest("GET", result.NextURL, nil)
+// Collect gathers the items yielded by All. If a page fails, the items
+// gathered so far are returned alongside the error, with hasMore set when
+// there are any, so callers can emit partial results (for example after
+// Ctrl-C) without mistaking them for the full list.
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, bool, error) {
+	var items []T
+	for item, err := range p.All(ctx) {
 		if err != nil {
-			return nil, fmt.Errorf("building next page request: %w", err)
+			return items, len(items) > 0, err
 		}
-		req.Header.Set("Authorization", "token "+c.Token)
-		req.Header.Set("Accept", "application/json")
+		items = append(items, item)
 	}
+	return items, p.HasMore(), nil
+}
+
+func FetchSinglePage[T any](ctx context.Context, c *Client, path string, params map[string]string) ([]T, bool, error) {
+	return NewPager[T](c, path, params, false).Collect(ctx)
+}
 
-	return all, nil
+// CollectAllPages follows Link headers until the last page. If a page fails,
+// the items gathered so far are returned alongside the error.
+func CollectAllPages[T any](ctx context.Context, c *Client, path string, params map[string]string) ([]T, error) {
+	items, _, err := NewPager[T](c, path, params, true).Collect(ctx)
+	return items, err
 }
 
 func toURLValues(params map[string]string) map[string][]string {
@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -91,7 +93,7 @@ func TestFetchSinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := FetchSinglePage[testItem](c, "/test", nil)
+	result, hasMore, err := FetchSinglePage[testItem](context.Background(), c, "/test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -115,7 +117,7 @@ func TestFetchSinglePageWithPagination(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := FetchSinglePage[testItem](c, "/test", nil)
+	result, hasMore, err := FetchSinglePage[testItem](context.Background(), c, "/test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -143,7 +145,7 @@ func TestCollectAllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := CollectAllPages[testItem](c, "/test", nil)
+	result, err := CollectAllPages[testItem](context.Background(), c, "/test", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -154,3 +156,93 @@ func TestCollectAllPages(t *testing.T) {
 		t.Errorf("expected 2 API calls, got %d", callCount)
 	}
 }
+
+func newTwoPageServer(t *testing.T, calls *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/page2" {
+			json.NewEncoder(w).Encode([]testItem{{ID: "3"}})
+			return
+		}
+		w.Header().Set("Link", `<http://`+r.Host+`/page2>; rel="next"`)
+		json.NewEncoder(w).Encode([]testItem{{ID: "1"}, {ID: "2"}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPager_AllStreamsEveryPage(t *testing.T) {
+	calls := 0
+	server := newTwoPageServer(t, &calls)
+	c := New(server.URL, "test-token", 30)
+
+	pager := NewPager[testItem](c, "/test", nil, true)
+	var ids []string
+	for item, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("expected items 1,2,3, got %v", ids)
+	}
+	if pager.HasMore() {
+		t.Error("expected HasMore=false after the last page")
+	}
+}
+
+func TestPager_FetchesNextPageLazily(t *testing.T) {
+	calls := 0
+	server := newTwoPageServer(t, &calls)
+	c := New(server.URL, "test-token", 30)
+
+	pager := NewPager[testItem](c, "/test", nil, true)
+	for _, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		break
+	}
+	if calls != 1 {
+		t.Errorf("expected only the first page to be fetched, got %d calls", calls)
+	}
+	if !pager.HasMore() {
+		t.Error("expected HasMore=true after stopping early")
+	}
+}
+
+func TestPager_SinglePageReportsNextPage(t *testing.T) {
+	calls := 0
+	server := newTwoPageServer(t, &calls)
+	c := New(server.URL, "test-token", 30)
+
+	items, hasMore, err := NewPager[testItem](c, "/test", nil, false).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || !hasMore || calls != 1 {
+		t.Errorf("expected 2 items from 1 call with hasMore, got %d items, hasMore=%v, %d calls", len(items), hasMore, calls)
+	}
+}
+
+func TestPager_YieldsErrorOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	c := New(server.URL, "test-token", 30)
+
+	errs := 0
+	for _, err := range NewPager[testItem](c, "/test", nil, true).All(context.Background()) {
+		if err == nil {
+			t.Fatal("expected only an error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected the error to be yielded once, got %d", errs)
+	}
+}
@@ -1,23 +1,24 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) ListProjects(orgID string, allPages bool) ([]models.Project, bool, error) {
+func (c *Client) ListProjects(ctx context.Context, orgID string, allPages bool) ([]models.Project, bool, error) {
+	return c.ProjectsPager(orgID, allPages).Collect(ctx)
+}
+
+func (c *Client) ProjectsPager(orgID string, allPages bool) *Pager[models.Project] {
 	path := fmt.Sprintf("/organizations/%s/projects", orgID)
-	if allPages {
-		items, err := CollectAllPages[models.Project](c, path, nil)
-		return items, false, err
-	}
-	return FetchSinglePage[models.Project](c, path, nil)
+	return NewPager[models.Project](c, path, nil, allPages)
 }
 
-func (c *Client) GetProject(projectID string) (*models.Project, error) {
+func (c *Client) GetProject(ctx context.Context, projectID string) (*models.Project, error) {
 	path := fmt.Sprintf("/projects/%s", projectID)
-	req, err := c.newRequest("GET", path, nil)
+	req, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
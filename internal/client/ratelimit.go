@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the per-token limit of the Bugsnag Data Access API.
+const DefaultRequestsPerMinute = 10
+
+// rateLimiter is a token bucket shared by every request sent through a
+// Client. Callers reserve a token up front and sleep off any deficit, so
+// concurrent callers queue fairly without holding the lock while waiting.
+type rateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	interval time.Duration // time to earn one token
+	last     time.Time
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// newRateLimiter returns a limiter allowing perMinute requests per minute
+// with bursts of the same size, or nil (no limit) when perMinute <= 0.
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		capacity: float64(perMinute),
+		tokens:   float64(perMinute),
+		interval: time.Minute / time.Duration(perMinute),
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.capacity, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// wait blocks until the caller may send a request, or until ctx is done,
+// and returns the time spent waiting. A nil limiter never waits.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+	d := l.reserve()
+	if d > 0 {
+		if err := l.sleep(ctx, d); err != nil {
+			l.cancel()
+			return 0, err
+		}
+	}
+	return d, nil
+}
+
+// cancel returns a token reserved by a caller that gave up waiting.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.capacity, l.tokens+1)
+}
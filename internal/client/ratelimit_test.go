@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock drives a rateLimiter deterministically: sleeping advances time.
+type fakeClock struct {
+	mu    sync.Mutex
+	t     time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept = append(c.slept, d)
+	return ctx.Err()
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestLimiter(perMinute int) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := newRateLimiter(perMinute)
+	l.now = clock.now
+	l.sleep = clock.sleep
+	return l, clock
+}
+
+func TestRateLimiter_BurstThenWait(t *testing.T) {
+	l, clock := newTestLimiter(6) // one token every 10s
+	for i := 0; i < 6; i++ {
+		if d, _ := l.wait(context.Background()); d != 0 {
+			t.Fatalf("request %d within burst waited %v", i, d)
+		}
+	}
+	if d, _ := l.wait(context.Background()); d != 10*time.Second {
+		t.Errorf("expected 10s wait once the bucket is empty, got %v", d)
+	}
+	if d, _ := l.wait(context.Background()); d != 20*time.Second {
+		t.Errorf("expected queued request to wait 20s, got %v", d)
+	}
+	if len(clock.slept) != 2 {
+		t.Errorf("expected 2 sleeps, got %v", clock.slept)
+	}
+}
+
+func TestRateLimiter_Refills(t *testing.T) {
+	l, clock := newTestLimiter(60) // one token per second
+	for i := 0; i < 60; i++ {
+		l.wait(context.Background())
+	}
+	clock.advance(5 * time.Second)
+	for i := 0; i < 5; i++ {
+		if d, _ := l.wait(context.Background()); d != 0 {
+			t.Fatalf("expected refilled token %d without waiting, got %v", i, d)
+		}
+	}
+	if d, _ := l.wait(context.Background()); d != time.Second {
+		t.Errorf("expected 1s wait after refill is spent, got %v", d)
+	}
+
+	clock.advance(time.Hour)
+	l.reserve()
+	if l.tokens != l.capacity-1 {
+		t.Errorf("expected refill to be capped at capacity, got %v tokens", l.tokens)
+	}
+}
+
+func TestRateLimiter_ConcurrentCallers(t *testing.T) {
+	l, clock := newTestLimiter(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.wait(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// 10 requests fit the burst; the other 20 queue at 6s intervals.
+	if len(clock.slept) != 20 {
+		t.Fatalf("expected 20 callers to wait, got %d", len(clock.slept))
+	}
+	var longest time.Duration
+	for _, d := range clock.slept {
+		longest = max(longest, d)
+	}
+	if longest != 120*time.Second {
+		t.Errorf("expected the last caller to wait 120s, got %v", longest)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	if newRateLimiter(0) != nil {
+		t.Error("expected no limiter for 0 requests per minute")
+	}
+	var l *rateLimiter
+	if d, _ := l.wait(context.Background()); d != 0 {
+		t.Errorf("nil limiter waited %v", d)
+	}
+}
+
+func TestClient_RateLimitAppliesToEveryPage(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, serverURL))
+		}
+		json.NewEncoder(w).Encode([]map[string]string{{"id": "x"}})
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	c := New(server.URL, "test-token", 30)
+	c.SetRateLimit(1)
+	clock := &fakeClock{t: time.Now()}
+	c.limiter.now = clock.now
+	c.limiter.sleep = clock.sleep
+
+	if _, err := CollectAllPages[map[string]string](context.Background(), c, "/items", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clock.slept) != 1 || clock.slept[0] != time.Minute {
+		t.Errorf("expected the next-page request to wait a full minute, got %v", clock.slept)
+	}
+}
+
+func TestNew_DefaultRateLimit(t *testing.T) {
+	c := New("https://api.bugsnag.com", "tok", 30)
+	if c.limiter == nil || c.limiter.capacity != DefaultRequestsPerMinute {
+		t.Errorf("expected default limiter of %d requests per minute", DefaultRequestsPerMinute)
+	}
+	c.SetRateLimit(0)
+	if c.limiter != nil {
+		t.Error("expected SetRateLimit(0) to disable the limiter")
+	}
+}
+
+func TestRateLimiter_CanceledWaitReturnsToken(t *testing.T) {
+	l, _ := newTestLimiter(1)
+	l.wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.wait(ctx); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if l.tokens != 0 {
+		t.Errorf("expected the abandoned reservation to be returned, got %v tokens", l.tokens)
+	}
+}
@@ -1,16 +1,79 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) ListReleases(projectID string, allPages bool) ([]models.Release, bool, error) {
-	path := fmt.Sprintf("/projects/%s/releases", projectID)
-	if allPages {
-		items, err := CollectAllPages[models.Release](c, path, nil)
-		return items, false, err
+// ListReleasesOptions selects releases. When ReleaseGroupID is set the
+// releases of that group are listed instead of the project's, and
+// ReleaseStage and Sort do not apply.
+type ListReleasesOptions struct {
+	ProjectID      string
+	ReleaseGroupID string
+	ReleaseStage   string
+	Sort           string
+	AllPages       bool
+}
+
+func (c *Client) ListReleases(ctx context.Context, projectID string, allPages bool) ([]models.Release, bool, error) {
+	return c.ReleasesPager(ListReleasesOptions{ProjectID: projectID, AllPages: allPages}).Collect(ctx)
+}
+
+func (c *Client) ReleasesPager(opts ListReleasesOptions) *Pager[models.Release] {
+	if opts.ReleaseGroupID != "" {
+		path := fmt.Sprintf("/release_groups/%s/releases", opts.ReleaseGroupID)
+		return NewPager[models.Release](c, path, nil, opts.AllPages)
+	}
+
+	path := fmt.Sprintf("/projects/%s/releases", opts.ProjectID)
+	params := map[string]string{}
+	if opts.ReleaseStage != "" {
+		params["release_stage"] = opts.ReleaseStage
+	}
+	if opts.Sort != "" {
+		params["sort"] = opts.Sort
+	}
+	return NewPager[models.Release](c, path, params, opts.AllPages)
+}
+
+func (c *Client) GetRelease(ctx context.Context, releaseID string) (*models.Release, error) {
+	path := fmt.Sprintf("/releases/%s", releaseID)
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var release models.Release
+	_, err = c.do(req, &release)
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// ListReleaseGroupsOptions selects a project's release groups, the releases
+// of one version in one release stage.
+type ListReleaseGroupsOptions struct {
+	ProjectID    string
+	ReleaseStage string
+	// TopOnly keeps the groups Bugsnag marks as top releases.
+	TopOnly  bool
+	AllPages bool
+}
+
+func (c *Client) ListReleaseGroups(ctx context.Context, opts ListReleaseGroupsOptions) ([]models.ReleaseGroup, bool, error) {
+	return c.ReleaseGroupsPager(opts).Collect(ctx)
+}
+
+func (c *Client) ReleaseGroupsPager(opts ListReleaseGroupsOptions) *Pager[models.ReleaseGroup] {
+	path := fmt.Sprintf("/projects/%s/release_groups", opts.ProjectID)
+	params := map[string]string{
+		"release_stage_name": opts.ReleaseStage,
+		"top_only":           strconv.FormatBool(opts.TopOnly),
 	}
-	return FetchSinglePage[models.Release](c, path, nil)
+	return NewPager[models.ReleaseGroup](c, path, params, opts.AllPages)
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -51,7 +52,7 @@ func errorHandler(statusCode int, message string) http.HandlerFunc {
 func TestNewRequestWithBody(t *testing.T) {
 	c := New("https://api.bugsnag.com", "test-token", 30)
 	bodyStr := `{"message":"hello"}`
-	req, err := c.newRequestWithBody("POST", "/some/path", strings.NewReader(bodyStr))
+	req, err := c.newRequestWithBody(context.Background(), "POST", "/some/path", strings.NewReader(bodyStr))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,7 +104,7 @@ func TestListOrganizations_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListOrganizations(false)
+	result, hasMore, err := c.ListOrganizations(context.Background(), false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -132,7 +133,7 @@ func TestListOrganizations_SinglePageWithPagination(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListOrganizations(false)
+	result, hasMore, err := c.ListOrganizations(context.Background(), false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -164,7 +165,7 @@ func TestListOrganizations_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListOrganizations(true)
+	result, hasMore, err := c.ListOrganizations(context.Background(), true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -184,7 +185,7 @@ func TestListOrganizations_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "bad-token", 30)
-	_, _, err := c.ListOrganizations(false)
+	_, _, err := c.ListOrganizations(context.Background(), false)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -216,7 +217,7 @@ func TestListProjects_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListProjects("org-abc", false)
+	result, hasMore, err := c.ListProjects(context.Background(), "org-abc", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -250,7 +251,7 @@ func TestListProjects_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListProjects("org-abc", true)
+	result, hasMore, err := c.ListProjects(context.Background(), "org-abc", true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -270,7 +271,7 @@ func TestListProjects_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListProjects("bad-org", false)
+	_, _, err := c.ListProjects(context.Background(), "bad-org", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -307,7 +308,7 @@ func TestGetProject_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetProject("proj-123")
+	result, err := c.GetProject(context.Background(), "proj-123")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -333,7 +334,7 @@ func TestGetProject_NotFound(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetProject("nonexistent")
+	_, err := c.GetProject(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -348,7 +349,7 @@ func TestGetProject_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetProject("proj-123")
+	_, err := c.GetProject(context.Background(), "proj-123")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -394,7 +395,7 @@ func TestListErrors_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListErrors(ListErrorsOptions{ProjectID: "proj-1"})
+	result, hasMore, err := c.ListErrors(context.Background(), ListErrorsOptions{ProjectID: "proj-1"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -435,7 +436,7 @@ func TestListErrors_WithFilters(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListErrors(ListErrorsOptions{
+	result, _, err := c.ListErrors(context.Background(), ListErrorsOptions{
 		ProjectID: "proj-1",
 		Status:    "open",
 		Severity:  "error",
@@ -466,7 +467,7 @@ func TestListErrors_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListErrors(ListErrorsOptions{
+	result, hasMore, err := c.ListErrors(context.Background(), ListErrorsOptions{
 		ProjectID: "proj-1",
 		AllPages:  true,
 	})
@@ -489,7 +490,7 @@ func TestListErrors_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListErrors(ListErrorsOptions{ProjectID: "bad-proj"})
+	_, _, err := c.ListErrors(context.Background(), ListErrorsOptions{ProjectID: "bad-proj"})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -522,7 +523,7 @@ func TestGetError_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetError("proj-1", "err-123")
+	result, err := c.GetError(context.Background(), "proj-1", "err-123")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -548,7 +549,7 @@ func TestGetError_NotFound(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetError("proj-1", "nonexistent")
+	_, err := c.GetError(context.Background(), "proj-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -563,7 +564,7 @@ func TestGetError_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetError("proj-1", "err-123")
+	_, err := c.GetError(context.Background(), "proj-1", "err-123")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -573,6 +574,173 @@ func TestGetError_ServerError(t *testing.T) {
 	}
 }
 
+func TestUpdateError_Fix(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/errors/err-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		bodyBytes, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		if err := json.Unmarshal(bodyBytes, &body); err != nil {
+			t.Fatalf("unmarshaling request body: %v", err)
+		}
+		if body["operation"] != "fix" {
+			t.Errorf("expected operation=fix, got %v", body["operation"])
+		}
+		if len(body) != 1 {
+			t.Errorf("expected only the operation in body, got %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.BugsnagError{ID: "err-1", Status: "fixed"})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, err := c.UpdateError(context.Background(), "proj-1", "err-1", ErrorUpdate{Operation: "fix"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "fixed" {
+		t.Errorf("expected status=fixed, got %s", result.Status)
+	}
+}
+
+func TestUpdateError_Snooze(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		var body struct {
+			Operation   string             `json:"operation"`
+			ReopenRules models.ReopenRules `json:"reopen_rules"`
+		}
+		json.Unmarshal(bodyBytes, &body)
+		if body.Operation != "snooze" {
+			t.Errorf("expected operation=snooze, got %s", body.Operation)
+		}
+		if body.ReopenRules.ReopenIf != "occurs_after" || body.ReopenRules.Seconds != 3600 {
+			t.Errorf("unexpected reopen rules: %+v", body.ReopenRules)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"err-1","status":"snoozed","reopen_rules":{"reopen_if":"occurs_after","seconds":3600}}`))
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, err := c.UpdateError(context.Background(), "proj-1", "err-1", ErrorUpdate{
+		Operation:   "snooze",
+		ReopenRules: &models.ReopenRules{ReopenIf: "occurs_after", Seconds: 3600},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ReopenRules == nil || result.ReopenRules.Seconds != 3600 {
+		t.Errorf("expected reopen rules in response, got %+v", result.ReopenRules)
+	}
+}
+
+func TestUpdateError_Unassign(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		json.Unmarshal(bodyBytes, &body)
+		if body["operation"] != "assign" {
+			t.Errorf("expected operation=assign, got %v", body["operation"])
+		}
+		v, ok := body["assigned_collaborator_id"]
+		if !ok || v != nil {
+			t.Errorf("expected assigned_collaborator_id=null, got %v (present=%v)", v, ok)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.BugsnagError{ID: "err-1"})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	if _, err := c.UpdateError(context.Background(), "proj-1", "err-1", ErrorUpdate{Operation: "unassign"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateError_APIError(t *testing.T) {
+	server := newTestServer(t, errorHandler(422, "Invalid operation"))
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	_, err := c.UpdateError(context.Background(), "proj-1", "err-1", ErrorUpdate{Operation: "fix"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	apiErr := err.(*APIError)
+	if apiErr.StatusCode != 422 || apiErr.Message != "Invalid operation" {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
+}
+
+func TestBulkUpdateErrors(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/projects/proj-1/errors" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		ids := r.URL.Query()["error_ids[]"]
+		if len(ids) != 2 || ids[0] != "err-1" || ids[1] != "err-2" {
+			t.Errorf("unexpected error_ids: %v", ids)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["operation"] != "ignore" {
+			t.Errorf("expected operation=ignore, got %v", body["operation"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	if err := c.BulkUpdateErrors(context.Background(), "proj-1", []string{"err-1", "err-2"}, ErrorUpdate{Operation: "ignore"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBulkUpdateErrors_APIError(t *testing.T) {
+	server := newTestServer(t, errorHandler(400, "Bad request"))
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	err := c.BulkUpdateErrors(context.Background(), "proj-1", []string{"err-1"}, ErrorUpdate{Operation: "fix"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if apiErr := err.(*APIError); apiErr.StatusCode != 400 {
+		t.Errorf("expected 400, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestListErrors_WithGenericFilters(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filters[user.email][][type]") != "eq" || q.Get("filters[user.email][][value]") != "a@b.c" {
+			t.Errorf("expected user.email filter, got query %s", r.URL.RawQuery)
+		}
+		if q.Get("status") != "open" {
+			t.Errorf("expected status=open, got %q", q.Get("status"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.BugsnagError{})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	_, _, err := c.ListErrors(context.Background(), ListErrorsOptions{
+		ProjectID: "proj-1",
+		Status:    "open",
+		Filters:   []Filter{{Field: "user.email", Op: "eq", Value: "a@b.c"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // ===========================================================================
 // Events
 // ===========================================================================
@@ -592,7 +760,7 @@ func TestListEvents_ForProject_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListEvents("proj-1", "", false)
+	result, hasMore, err := c.ListEvents(context.Background(), ListEventsOptions{ProjectID: "proj-1"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -621,7 +789,7 @@ func TestListEvents_ForError_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListEvents("proj-1", "err-1", false)
+	result, _, err := c.ListEvents(context.Background(), ListEventsOptions{ProjectID: "proj-1", ErrorID: "err-1"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -649,7 +817,7 @@ func TestListEvents_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListEvents("proj-1", "", true)
+	result, hasMore, err := c.ListEvents(context.Background(), ListEventsOptions{ProjectID: "proj-1", AllPages: true})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -669,7 +837,7 @@ func TestListEvents_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListEvents("proj-1", "", false)
+	_, _, err := c.ListEvents(context.Background(), ListEventsOptions{ProjectID: "proj-1"})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -702,7 +870,7 @@ func TestGetEvent_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetEvent("proj-1", "evt-abc")
+	result, err := c.GetEvent(context.Background(), "proj-1", "evt-abc")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -720,12 +888,42 @@ func TestGetEvent_Success(t *testing.T) {
 	}
 }
 
+func TestGetLatestEvent_Success(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/errors/err-1/latest_event" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.Event{ID: "evt-latest", ErrorID: "err-1"})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, err := c.GetLatestEvent(context.Background(), "proj-1", "err-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ID != "evt-latest" {
+		t.Errorf("expected ID=evt-latest, got %s", result.ID)
+	}
+}
+
+func TestGetLatestEvent_NotFound(t *testing.T) {
+	server := newTestServer(t, errorHandler(404, "Error not found"))
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	if _, err := c.GetLatestEvent(context.Background(), "proj-1", "nonexistent"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func TestGetEvent_NotFound(t *testing.T) {
 	server := newTestServer(t, errorHandler(404, "Event not found"))
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetEvent("proj-1", "nonexistent")
+	_, err := c.GetEvent(context.Background(), "proj-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -740,7 +938,7 @@ func TestGetEvent_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetEvent("proj-1", "evt-abc")
+	_, err := c.GetEvent(context.Background(), "proj-1", "evt-abc")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -750,6 +948,33 @@ func TestGetEvent_ServerError(t *testing.T) {
 	}
 }
 
+func TestListEvents_WithFilters(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/events" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("filters[app.release_stage][][value]") != "production" {
+			t.Errorf("expected release stage filter, got query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.Event{{ID: "ev-1"}})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, _, err := c.ListEvents(context.Background(), ListEventsOptions{
+		ProjectID: "proj-1",
+		Filters:   []Filter{{Field: "app.release_stage", Op: "eq", Value: "production"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(result))
+	}
+}
+
 // ===========================================================================
 // Trends
 // ===========================================================================
@@ -777,7 +1002,7 @@ func TestGetProjectTrends_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetProjectTrends("proj-1", "1d", 3)
+	result, err := c.GetProjectTrends(context.Background(), "proj-1", "1d", 3)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -810,7 +1035,7 @@ func TestGetProjectTrends_DefaultParams(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetProjectTrends("proj-1", "", 0)
+	result, err := c.GetProjectTrends(context.Background(), "proj-1", "", 0)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -824,7 +1049,7 @@ func TestGetProjectTrends_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetProjectTrends("bad-proj", "1d", 5)
+	_, err := c.GetProjectTrends(context.Background(), "bad-proj", "1d", 5)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -848,7 +1073,7 @@ func TestGetErrorTrends_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetErrorTrends("proj-1", "err-1")
+	result, err := c.GetErrorTrends(context.Background(), "proj-1", "err-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -865,7 +1090,7 @@ func TestGetErrorTrends_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetErrorTrends("proj-1", "err-1")
+	_, err := c.GetErrorTrends(context.Background(), "proj-1", "err-1")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -875,6 +1100,46 @@ func TestGetErrorTrends_APIError(t *testing.T) {
 	}
 }
 
+func TestGetTrends_ErrorWithRange(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/errors/err-1/trend" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		checks := map[string]string{
+			"resolution":                     "1h",
+			"filters[event.since][][type]":   "eq",
+			"filters[event.since][][value]":  "2024-06-01T00:00:00Z",
+			"filters[event.before][][value]": "2024-06-02T00:00:00Z",
+		}
+		for k, want := range checks {
+			if got := q.Get(k); got != want {
+				t.Errorf("%s = %q, want %q", k, got, want)
+			}
+		}
+		if q.Has("buckets_count") {
+			t.Errorf("unexpected buckets_count in %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.TrendBucket{})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	_, err := c.GetTrends(context.Background(), TrendOptions{
+		ProjectID:  "proj-1",
+		ErrorID:    "err-1",
+		Resolution: "1h",
+		Filters: []Filter{
+			{Field: "event.since", Op: "eq", Value: "2024-06-01T00:00:00Z"},
+			{Field: "event.before", Op: "eq", Value: "2024-06-02T00:00:00Z"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // ===========================================================================
 // Collaborators
 // ===========================================================================
@@ -894,7 +1159,7 @@ func TestListCollaborators_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListCollaborators("org-1", false)
+	result, hasMore, err := c.ListCollaborators(context.Background(), "org-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -928,7 +1193,7 @@ func TestListCollaborators_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListCollaborators("org-1", true)
+	result, hasMore, err := c.ListCollaborators(context.Background(), "org-1", true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -948,7 +1213,7 @@ func TestListCollaborators_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListCollaborators("bad-org", false)
+	_, _, err := c.ListCollaborators(context.Background(), "bad-org", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -980,7 +1245,7 @@ func TestListComments_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListComments("proj-1", "err-1", false)
+	result, hasMore, err := c.ListComments(context.Background(), "proj-1", "err-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1014,7 +1279,7 @@ func TestListComments_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListComments("proj-1", "err-1", true)
+	result, hasMore, err := c.ListComments(context.Background(), "proj-1", "err-1", true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1034,7 +1299,7 @@ func TestListComments_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListComments("proj-1", "bad-err", false)
+	_, _, err := c.ListComments(context.Background(), "proj-1", "bad-err", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1085,7 +1350,7 @@ func TestCreateComment_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.CreateComment("proj-1", "err-1", "This is a test comment")
+	result, err := c.CreateComment(context.Background(), "proj-1", "err-1", "This is a test comment")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1114,7 +1379,7 @@ func TestCreateComment_EmptyMessage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.CreateComment("proj-1", "err-1", "")
+	result, err := c.CreateComment(context.Background(), "proj-1", "err-1", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1128,7 +1393,7 @@ func TestCreateComment_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.CreateComment("proj-1", "err-1", "test")
+	_, err := c.CreateComment(context.Background(), "proj-1", "err-1", "test")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1146,7 +1411,7 @@ func TestCreateComment_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.CreateComment("proj-1", "err-1", "boom")
+	_, err := c.CreateComment(context.Background(), "proj-1", "err-1", "boom")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1193,7 +1458,7 @@ func TestListReleases_SinglePage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListReleases("proj-1", false)
+	result, hasMore, err := c.ListReleases(context.Background(), "proj-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1236,7 +1501,7 @@ func TestListReleases_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListReleases("proj-1", true)
+	result, hasMore, err := c.ListReleases(context.Background(), "proj-1", true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1256,7 +1521,7 @@ func TestListReleases_APIError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListReleases("bad-proj", false)
+	_, _, err := c.ListReleases(context.Background(), "bad-proj", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1266,6 +1531,132 @@ func TestListReleases_APIError(t *testing.T) {
 	}
 }
 
+func TestReleasesPager_Filters(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/releases" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("release_stage"); got != "production" {
+			t.Errorf("expected release_stage=production, got %q", got)
+		}
+		if got := r.URL.Query().Get("sort"); got != "percent_of_sessions" {
+			t.Errorf("expected sort=percent_of_sessions, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.Release{{ID: "rel-1"}})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, _, err := c.ReleasesPager(ListReleasesOptions{
+		ProjectID:    "proj-1",
+		ReleaseStage: "production",
+		Sort:         "percent_of_sessions",
+	}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 release, got %d", len(result))
+	}
+}
+
+func TestReleasesPager_ReleaseGroup(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release_groups/grp-1/releases" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.Release{{ID: "rel-1"}, {ID: "rel-2"}})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, _, err := c.ReleasesPager(ListReleasesOptions{ReleaseGroupID: "grp-1"}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(result))
+	}
+}
+
+func TestGetRelease_Success(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/rel-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.Release{
+			ID:            "rel-1",
+			Version:       "1.0.0",
+			SourceControl: &models.SourceControl{Provider: "github", Revision: "abc123"},
+		})
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	release, err := c.GetRelease(context.Background(), "rel-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if release.Version != "1.0.0" {
+		t.Errorf("expected Version=1.0.0, got %s", release.Version)
+	}
+	if release.SourceControl == nil || release.SourceControl.Revision != "abc123" {
+		t.Errorf("unexpected source control: %+v", release.SourceControl)
+	}
+}
+
+func TestGetRelease_APIError(t *testing.T) {
+	server := newTestServer(t, errorHandler(404, "Release not found"))
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	_, err := c.GetRelease(context.Background(), "missing")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	apiErr := err.(*APIError)
+	if apiErr.StatusCode != 404 {
+		t.Errorf("expected 404, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestListReleaseGroups(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/proj-1/release_groups" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("release_stage_name"); got != "production" {
+			t.Errorf("expected release_stage_name=production, got %q", got)
+		}
+		if got := r.URL.Query().Get("top_only"); got != "true" {
+			t.Errorf("expected top_only=true, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"grp-1","app_version":"2.0.0","release_stage_name":"production","releases_count":3,"top_release_group":true,"total_sessions_count":500}]`))
+	})
+	defer server.Close()
+
+	c := New(server.URL, "test-token", 30)
+	result, _, err := c.ListReleaseGroups(context.Background(), ListReleaseGroupsOptions{
+		ProjectID:    "proj-1",
+		ReleaseStage: "production",
+		TopOnly:      true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 release group, got %d", len(result))
+	}
+	g := result[0]
+	if g.AppVersion != "2.0.0" || g.ReleasesCount != 3 || !g.TopReleaseGroup || g.TotalSessionsCount != 500 {
+		t.Errorf("unexpected release group: %+v", g)
+	}
+}
+
 // ===========================================================================
 // Stability Trend
 // ===========================================================================
@@ -1311,7 +1702,7 @@ func TestGetStabilityTrend_Success(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetStabilityTrend("proj-1", "production")
+	result, err := c.GetStabilityTrend(context.Background(), "proj-1", "production")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1361,7 +1752,7 @@ func TestGetStabilityTrend_NoReleaseStage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetStabilityTrend("proj-1", "")
+	result, err := c.GetStabilityTrend(context.Background(), "proj-1", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1378,7 +1769,7 @@ func TestGetStabilityTrend_NotFound(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetStabilityTrend("bad-proj", "production")
+	_, err := c.GetStabilityTrend(context.Background(), "bad-proj", "production")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1393,7 +1784,7 @@ func TestGetStabilityTrend_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, err := c.GetStabilityTrend("proj-1", "production")
+	_, err := c.GetStabilityTrend(context.Background(), "proj-1", "production")
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1441,14 +1832,14 @@ func TestAuthHeaderSentOnAllRequests(t *testing.T) {
 	c := New(server.URL, "secret-token-123", 30)
 
 	// Exercise various resource methods to verify auth header is always sent
-	c.ListOrganizations(false)
-	c.GetProject("p")
-	c.GetError("p", "e")
-	c.GetEvent("p", "e")
-	c.GetProjectTrends("p", "", 0)
-	c.GetErrorTrends("p", "e")
-	c.GetStabilityTrend("p", "")
-	c.CreateComment("p", "e", "msg")
+	c.ListOrganizations(context.Background(), false)
+	c.GetProject(context.Background(), "p")
+	c.GetError(context.Background(), "p", "e")
+	c.GetEvent(context.Background(), "p", "e")
+	c.GetProjectTrends(context.Background(), "p", "", 0)
+	c.GetErrorTrends(context.Background(), "p", "e")
+	c.GetStabilityTrend(context.Background(), "p", "")
+	c.CreateComment(context.Background(), "p", "e", "msg")
 }
 
 // ===========================================================================
@@ -1476,7 +1867,7 @@ func TestListOrganizations_ThreePages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListOrganizations(true)
+	result, _, err := c.ListOrganizations(context.Background(), true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1512,7 +1903,7 @@ func TestListReleases_WithSourceControl(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListReleases("proj-1", false)
+	result, _, err := c.ListReleases(context.Background(), "proj-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1563,7 +1954,7 @@ func TestGetError_WithNestedObjects(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetError("proj-1", "err-nested")
+	result, err := c.GetError(context.Background(), "proj-1", "err-nested")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1618,7 +2009,7 @@ func TestCreateComment_SpecialCharactersInMessage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.CreateComment("proj-1", "err-1", msg)
+	result, err := c.CreateComment(context.Background(), "proj-1", "err-1", msg)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1651,7 +2042,7 @@ func TestListErrors_AllPages_ErrorOnSecondPage(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	_, _, err := c.ListErrors(ListErrorsOptions{ProjectID: "proj-1", AllPages: true})
+	_, _, err := c.ListErrors(context.Background(), ListErrorsOptions{ProjectID: "proj-1", AllPages: true})
 	if err == nil {
 		t.Fatal("expected error when second page fails")
 	}
@@ -1692,7 +2083,7 @@ func TestGetEvent_WithRawJSONFields(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, err := c.GetEvent("proj-1", "evt-raw")
+	result, err := c.GetEvent(context.Background(), "proj-1", "evt-raw")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1746,7 +2137,7 @@ func TestListEvents_ForError_AllPages(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListEvents("proj-1", "err-1", true)
+	result, hasMore, err := c.ListEvents(context.Background(), ListEventsOptions{ProjectID: "proj-1", ErrorID: "err-1", AllPages: true})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1770,7 +2161,7 @@ func TestListOrganizations_EmptyResult(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, hasMore, err := c.ListOrganizations(false)
+	result, hasMore, err := c.ListOrganizations(context.Background(), false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1787,7 +2178,7 @@ func TestListProjects_EmptyResult(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListProjects("org-1", false)
+	result, _, err := c.ListProjects(context.Background(), "org-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1801,7 +2192,7 @@ func TestListReleases_EmptyResult(t *testing.T) {
 	defer server.Close()
 
 	c := New(server.URL, "test-token", 30)
-	result, _, err := c.ListReleases("proj-1", false)
+	result, _, err := c.ListReleases(context.Background(), "proj-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried after a
+	// rate limit, gateway error or timeout before giving up.
+	DefaultMaxRetries = 3
+
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+	// retryAfterLimit caps server-requested waits so a bad header cannot
+	// stall the CLI indefinitely.
+	retryAfterLimit = 5 * time.Minute
+)
+
+// send performs req, retrying on 429, 502, 503, 504 and network timeouts.
+// Responses with a status >= 400 that are not retried are turned into an
+// *APIError. On success the caller owns the response body. Waiting for the
+// rate limiter or between retries stops as soon as the request's context is
+// done.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		waited, err := c.limiter.wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if waited > 0 && c.Log != nil {
+			fmt.Fprintf(c.Log, "rate limit: waited %s before %s %s\n", waited.Round(time.Millisecond), req.Method, req.URL.Path)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt < c.MaxRetries && isRetryableNetworkError(req, err) {
+				if err := c.waitRetry(req, attempt, backoff(attempt), err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("network error: %w", err)
+		}
+
+		if resp.StatusCode < 400 {
+			return resp, nil
+		}
+
+		if attempt < c.MaxRetries && isRetryableStatus(req, resp.StatusCode) {
+			delay := retryDelay(resp, attempt)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if err := c.waitRetry(req, attempt, delay, resp.Status); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		apiErr := parseAPIError(resp)
+		resp.Body.Close()
+		return resp, apiErr
+	}
+}
+
+func (c *Client) waitRetry(req *http.Request, attempt int, delay time.Duration, reason string) error {
+	c.retries.Add(1)
+	if c.Log != nil {
+		fmt.Fprintf(c.Log, "retry %d/%d for %s %s in %s: %s\n",
+			attempt+1, c.MaxRetries, req.Method, req.URL.Path, delay.Round(time.Millisecond), reason)
+	}
+	sleep := c.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	return sleep(req.Context(), delay)
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func isRetryableStatus(req *http.Request, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		// Rate-limited requests were not processed, so any method is safe.
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return req.Method != http.MethodPost
+	}
+	return false
+}
+
+func isRetryableNetworkError(req *http.Request, err error) bool {
+	if req.Method == http.MethodPost {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryDelay honors Retry-After (seconds or HTTP date) and
+// X-RateLimit-Reset (Unix seconds) before falling back to backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, retryAfterLimit)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return min(max(time.Until(t), 0), retryAfterLimit)
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return min(max(time.Until(time.Unix(reset, 0)), 0), retryAfterLimit)
+		}
+	}
+	return backoff(attempt)
+}
+
+// backoff returns an exponential delay with jitter in [d/2, d). The shift is
+// capped well past retryMaxDelay so that it cannot overflow on high attempt
+// counts.
+func backoff(attempt int) time.Duration {
+	d := min(retryBaseDelay<<min(attempt, 20), retryMaxDelay)
+	return d/2 + rand.N(d/2)
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client whose sleeps are recorded instead of
+// performed.
+func newRetryTestClient(baseURL string, delays *[]time.Duration) *Client {
+	c := New(baseURL, "test-token", 30)
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return c
+}
+
+func TestSend_RetriesServiceUnavailable(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "p1"})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	var log bytes.Buffer
+	c := newRetryTestClient(server.URL, &delays)
+	c.Log = &log
+
+	project, err := c.GetProject(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project.ID != "p1" {
+		t.Errorf("unexpected project: %+v", project)
+	}
+	if calls != 3 || c.Retries() != 2 || len(delays) != 2 {
+		t.Errorf("expected 3 calls and 2 retries, got calls=%d retries=%d delays=%v", calls, c.Retries(), delays)
+	}
+	if !strings.Contains(log.String(), "retry 1/3 for GET /projects/p1") || !strings.Contains(log.String(), "retry 2/3") {
+		t.Errorf("unexpected retry log: %q", log.String())
+	}
+}
+
+func TestSend_HonorsRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]string{})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	if _, _, err := c.ListOrganizations(context.Background(), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(delays) != 1 || delays[0] != 7*time.Second {
+		t.Errorf("expected a single 7s wait, got %v", delays)
+	}
+}
+
+func TestSend_HonorsRateLimitReset(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(20*time.Second).Unix()))
+	d := retryDelay(resp, 0)
+	if d < 18*time.Second || d > 20*time.Second {
+		t.Errorf("expected ~20s delay from X-RateLimit-Reset, got %v", d)
+	}
+
+	resp.Header = http.Header{}
+	resp.Header.Set("Retry-After", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+	d = retryDelay(resp, 0)
+	if d < 8*time.Second || d > 10*time.Second {
+		t.Errorf("expected ~10s delay from HTTP-date Retry-After, got %v", d)
+	}
+
+	resp.Header = http.Header{}
+	resp.Header.Set("Retry-After", "86400")
+	if d := retryDelay(resp, 0); d != retryAfterLimit {
+		t.Errorf("expected Retry-After to be capped at %v, got %v", retryAfterLimit, d)
+	}
+}
+
+func TestSend_GivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("bad gateway"))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	c.MaxRetries = 2
+	_, err := c.GetProject(context.Background(), "p1")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502 APIError, got %v", err)
+	}
+	if apiErr.Message != "bad gateway" {
+		t.Errorf("expected body of final response in message, got %q", apiErr.Message)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestSend_NoRetryOnClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	if _, err := c.GetProject(context.Background(), "p1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 500 not to be retried, got %d calls", calls)
+	}
+}
+
+func TestSend_PostOnlyRetriedWhenRateLimited(t *testing.T) {
+	var bodies []string
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(status)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "c1"})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	if _, err := c.CreateComment(context.Background(), "p1", "e1", "hi"); err == nil {
+		t.Fatal("expected 503 on POST not to be retried")
+	}
+
+	bodies = nil
+	status = http.StatusTooManyRequests
+	if _, err := c.CreateComment(context.Background(), "p1", "e1", "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], `"hi"`) {
+		t.Errorf("expected the request body to be resent on retry, got %q", bodies)
+	}
+}
+
+func TestSend_RetriesTimeouts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "p1"})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	c.HTTPClient.Timeout = 50 * time.Millisecond
+	if _, err := c.GetProject(context.Background(), "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 || c.Retries() != 1 {
+		t.Errorf("expected timeout to be retried once, got calls=%d retries=%d", calls.Load(), c.Retries())
+	}
+}
+
+func TestCollectAllPages_RetryDoesNotRefetchEarlierPages(t *testing.T) {
+	var serverURL string
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		hits[page]++
+		switch {
+		case page == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, serverURL))
+			json.NewEncoder(w).Encode([]map[string]string{{"id": "1"}})
+		case hits[page] == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			json.NewEncoder(w).Encode([]map[string]string{{"id": "2"}})
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	var delays []time.Duration
+	c := newRetryTestClient(server.URL, &delays)
+	items, err := CollectAllPages[map[string]string](context.Background(), c, "/items", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("expected 2 items, got %d", len(items))
+	}
+	if hits[""] != 1 || hits["2"] != 2 {
+		t.Errorf("expected first page once and second page twice, got %v", hits)
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		d := min(retryBaseDelay<<attempt, retryMaxDelay)
+		got := backoff(attempt)
+		if got < d/2 || got >= d {
+			t.Errorf("backoff(%d) = %v, want in [%v, %v)", attempt, got, d/2, d)
+		}
+	}
+}
+
+func TestBackoffHighAttempt(t *testing.T) {
+	for _, attempt := range []int{34, 35, 63, 64, 1000} {
+		got := backoff(attempt)
+		if got < retryMaxDelay/2 || got >= retryMaxDelay {
+			t.Errorf("backoff(%d) = %v, want in [%v, %v)", attempt, got, retryMaxDelay/2, retryMaxDelay)
+		}
+	}
+}
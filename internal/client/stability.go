@@ -1,19 +1,20 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) GetStabilityTrend(projectID, releaseStage string) (*models.StabilityTrend, error) {
+func (c *Client) GetStabilityTrend(ctx context.Context, projectID, releaseStage string) (*models.StabilityTrend, error) {
 	path := fmt.Sprintf("/projects/%s/stability_trend", projectID)
 	params := map[string]string{}
 	if releaseStage != "" {
 		params["release_stage"] = releaseStage
 	}
 
-	req, err := c.newRequest("GET", path, toURLValues(params))
+	req, err := c.newRequest(ctx, "GET", path, toURLValues(params))
 	if err != nil {
 		return nil, err
 	}
@@ -1,34 +1,47 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
-func (c *Client) GetProjectTrends(projectID, resolution string, bucketsCount int) ([]models.TrendBucket, error) {
-	path := fmt.Sprintf("/projects/%s/trend", projectID)
-	params := map[string]string{}
-	if resolution != "" {
-		params["resolution"] = resolution
-	}
-	if bucketsCount > 0 {
-		params["buckets_count"] = fmt.Sprintf("%d", bucketsCount)
-	}
+// TrendOptions select the trend of a project, or of one of its errors when
+// ErrorID is set, and how its events are bucketed.
+type TrendOptions struct {
+	ProjectID    string
+	ErrorID      string
+	Resolution   string
+	BucketsCount int
+	// Filters restrict the events counted, such as event.since and
+	// event.before for a time range.
+	Filters []Filter
+}
 
-	req, err := c.newRequest("GET", path, toURLValues(params))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetProjectTrends(ctx context.Context, projectID, resolution string, bucketsCount int) ([]models.TrendBucket, error) {
+	return c.GetTrends(ctx, TrendOptions{ProjectID: projectID, Resolution: resolution, BucketsCount: bucketsCount})
+}
 
-	var buckets []models.TrendBucket
-	_, err = c.do(req, &buckets)
-	return buckets, err
+func (c *Client) GetErrorTrends(ctx context.Context, projectID, errorID string) ([]models.TrendBucket, error) {
+	return c.GetTrends(ctx, TrendOptions{ProjectID: projectID, ErrorID: errorID})
 }
 
-func (c *Client) GetErrorTrends(projectID, errorID string) ([]models.TrendBucket, error) {
-	path := fmt.Sprintf("/projects/%s/errors/%s/trend", projectID, errorID)
-	req, err := c.newRequest("GET", path, nil)
+// GetTrends returns the trend buckets selected by opts.
+func (c *Client) GetTrends(ctx context.Context, opts TrendOptions) ([]models.TrendBucket, error) {
+	path := fmt.Sprintf("/projects/%s/trend", opts.ProjectID)
+	if opts.ErrorID != "" {
+		path = fmt.Sprintf("/projects/%s/errors/%s/trend", opts.ProjectID, opts.ErrorID)
+	}
+	params := map[string]string{}
+	if opts.Resolution != "" {
+		params["resolution"] = opts.Resolution
+	}
+	if opts.BucketsCount > 0 {
+		params["buckets_count"] = fmt.Sprintf("%d", opts.BucketsCount)
+	}
+
+	req, err := c.newRequest(ctx, "GET", withFilters(path, opts.Filters), toURLValues(params))
 	if err != nil {
 		return nil, err
 	}
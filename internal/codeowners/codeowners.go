@@ -0,0 +1,237 @@
+// Package codeowners parses CODEOWNERS files in the GitHub and GitLab
+// syntax and finds the owners of a path.
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Locations are the places GitHub and GitLab look for a CODEOWNERS file,
+// relative to the repository root, in the order they are searched.
+var Locations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// Rule is one pattern line of a CODEOWNERS file.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	// Section is the GitLab section the rule belongs to, empty for rules
+	// before the first section and for GitHub files.
+	Section string
+	Line    int
+
+	re *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []Rule
+}
+
+var sectionRe = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// Parse reads a CODEOWNERS file. GitLab sections are supported: owners
+// listed on a section header apply to the section's rules that have none.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	var section string
+	var sectionOwners []string
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := sectionRe.FindStringSubmatch(line); m != nil {
+			section = strings.TrimSpace(m[1])
+			sectionOwners = owners(fields(m[2]))
+			continue
+		}
+
+		tokens := fields(line)
+		if len(tokens) == 0 {
+			// A lone escape unescapes to nothing: there is no pattern.
+			continue
+		}
+		rule := Rule{Pattern: tokens[0], Owners: owners(tokens[1:]), Section: section, Line: n}
+		if len(rule.Owners) == 0 && section != "" {
+			rule.Owners = sectionOwners
+		}
+		re, err := compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("CODEOWNERS line %d: %w", n, err)
+		}
+		rule.re = re
+		f.Rules = append(f.Rules, rule)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Load finds and parses the CODEOWNERS file of the repository at root,
+// returning the path it was read from.
+func Load(root string) (*File, string, error) {
+	for _, loc := range Locations {
+		p := filepath.Join(root, filepath.FromSlash(loc))
+		file, err := os.Open(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+		f, err := Parse(file)
+		return f, p, err
+	}
+	return nil, "", fmt.Errorf("no CODEOWNERS file found in %s (looked in %s)", root, strings.Join(Locations, ", "))
+}
+
+// Match returns the owners of path, a slash-separated path relative to the
+// repository root, and the rules that gave them. As on GitHub, the last
+// matching rule wins; with GitLab sections, the last match of each section
+// counts and their owners are combined.
+func (f *File) Match(path string) ([]string, []Rule) {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+
+	var sections []string
+	last := map[string]Rule{}
+	for _, r := range f.Rules {
+		if !r.re.MatchString(path) {
+			continue
+		}
+		if _, seen := last[r.Section]; !seen {
+			sections = append(sections, r.Section)
+		}
+		last[r.Section] = r
+	}
+
+	var owners []string
+	var rules []Rule
+	for _, s := range sections {
+		r := last[s]
+		rules = append(rules, r)
+		for _, o := range r.Owners {
+			if !slices.Contains(owners, o) {
+				owners = append(owners, o)
+			}
+		}
+	}
+	return owners, rules
+}
+
+// HasOwner reports whether owner is among owners. Handles are compared
+// case-insensitively, as GitHub and GitLab do.
+func HasOwner(owners []string, owner string) bool {
+	for _, o := range owners {
+		if strings.EqualFold(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// fields splits a line on whitespace, keeping backslash-escaped spaces and
+// "#" characters in the token and stopping at an inline comment.
+func fields(line string) []string {
+	var tokens []string
+	var cur strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ' ' || r == '\t':
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		case r == '#' && cur.Len() == 0 && len(tokens) > 0:
+			return tokens
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
+
+// owners keeps the tokens that look like owners: @user, @org/team or an
+// email address.
+func owners(tokens []string) []string {
+	var out []string
+	for _, t := range tokens {
+		if strings.Contains(t, "@") {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// compile turns a gitignore-style pattern into a regular expression matching
+// the paths it covers: the path itself or, for directories, anything below.
+// Patterns with a slash other than a trailing one are anchored to the root;
+// others match at any depth. As on GitHub, "docs/*" only covers the files
+// directly in docs.
+func compile(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(p, "/*") && !strings.HasSuffix(p, "/**"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,158 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const githubFile = `# Default owners
+*                       @acme/core
+
+*.js                    @acme/web
+/docs/*                 docs@acme.test
+/src/payments/          @acme/payments @ada
+src/**/checkout         @acme/checkout
+apps/                   @acme/apps
+/build/logs/            # no owners: unowned
+\#notes.txt             @acme/notes
+`
+
+func TestMatch_GitHub(t *testing.T) {
+	f, err := Parse(strings.NewReader(githubFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@acme/core"}},
+		{"web/app.js", []string{"@acme/web"}},
+		{"docs/getting-started.md", []string{"docs@acme.test"}},
+		{"docs/build/troubleshooting.md", []string{"@acme/core"}},
+		{"src/payments/charge.go", []string{"@acme/payments", "@ada"}},
+		{"/src/payments/refund/refund.go", []string{"@acme/payments", "@ada"}},
+		{"lib/src/payments/charge.go", []string{"@acme/core"}},
+		{"src/payments", []string{"@acme/core"}},
+		{"src/checkout/cart.go", []string{"@acme/checkout"}},
+		{"src/web/v2/checkout/cart.go", []string{"@acme/checkout"}},
+		{"services/apps/main.go", []string{"@acme/apps"}},
+		{"build/logs/today.log", nil},
+		{"#notes.txt", []string{"@acme/notes"}},
+	}
+	for _, tt := range tests {
+		got, _ := f.Match(tt.path)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+
+	_, rules := f.Match("src/payments/charge.go")
+	if len(rules) != 1 || rules[0].Pattern != "/src/payments/" || rules[0].Line != 6 {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+}
+
+func TestMatch_GitLabSections(t *testing.T) {
+	f, err := Parse(strings.NewReader(`* @acme/core
+
+[Backend] @acme/backend
+*.go
+/internal/billing/ @acme/billing
+
+^[Docs][2] @acme/docs
+*.md
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@acme/core", "@acme/backend"}},
+		{"internal/billing/invoice.go", []string{"@acme/core", "@acme/billing"}},
+		{"internal/billing/README.md", []string{"@acme/core", "@acme/billing", "@acme/docs"}},
+		{"package.json", []string{"@acme/core"}},
+	}
+	for _, tt := range tests {
+		got, _ := f.Match(tt.path)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+
+	_, rules := f.Match("internal/billing/README.md")
+	if len(rules) != 3 || rules[1].Section != "Backend" || rules[2].Section != "Docs" {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+}
+
+func TestParse_EscapedSpaces(t *testing.T) {
+	f, err := Parse(strings.NewReader(`/My\ Documents/ @ada # personal files`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := f.Match("My Documents/cv.pdf"); !reflect.DeepEqual(got, []string{"@ada"}) {
+		t.Errorf("expected @ada, got %v", got)
+	}
+}
+
+func TestParse_EmptyPattern(t *testing.T) {
+	f, err := Parse(strings.NewReader("*.go @acme/go\n\\\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.Rules) != 1 || f.Rules[0].Pattern != "*.go" {
+		t.Errorf("expected lines without a pattern to be skipped, got %+v", f.Rules)
+	}
+}
+
+func TestParse_InvalidPattern(t *testing.T) {
+	if _, err := Parse(strings.NewReader("*.go @acme/go\n/ @acme/root\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error for line 2, got %v", err)
+	}
+}
+
+func TestHasOwner(t *testing.T) {
+	owners := []string{"@Acme/Payments", "ada@example.com"}
+	if !HasOwner(owners, "@acme/payments") || !HasOwner(owners, "ada@example.com") {
+		t.Error("expected owners to match case-insensitively")
+	}
+	if HasOwner(owners, "@acme") {
+		t.Error("expected no partial match")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	if _, _, err := Load(root); err == nil || !strings.Contains(err.Error(), "no CODEOWNERS file found") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	for _, loc := range []string{"docs/CODEOWNERS", ".github/CODEOWNERS"} {
+		p := filepath.Join(root, filepath.FromSlash(loc))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("* @from-"+filepath.Base(filepath.Dir(p))+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, path, err := Load(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join(root, ".github", "CODEOWNERS") {
+		t.Errorf("expected .github/CODEOWNERS to take precedence, got %s", path)
+	}
+	if got, _ := f.Match("main.go"); !reflect.DeepEqual(got, []string{"@from-.github"}) {
+		t.Errorf("unexpected owners %v", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Package git runs the local git binary to read history from a checkout.
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is a commit as reported by git log or git blame.
+type Commit struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject"`
+}
+
+// run executes git in dir and returns its standard output.
+func run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// Blame returns the commit that last changed line of file, a path relative
+// to dir.
+func Blame(ctx context.Context, dir, file string, line int) (Commit, error) {
+	// blame takes a single argument after --end-of-options as the path,
+	// and rejects a -- separator after it.
+	out, err := run(ctx, dir, "blame", "--porcelain", "-L", fmt.Sprintf("%d,%d", line, line), "--end-of-options", file)
+	if err != nil {
+		return Commit{}, err
+	}
+	return parseBlame(out)
+}
+
+func parseBlame(out []byte) (Commit, error) {
+	var c Commit
+	s := bufio.NewScanner(bytes.NewReader(out))
+	if !s.Scan() {
+		return c, fmt.Errorf("git blame: empty output")
+	}
+	c.SHA, _, _ = strings.Cut(s.Text(), " ")
+	for s.Scan() {
+		key, value, _ := strings.Cut(s.Text(), " ")
+		switch key {
+		case "author":
+			c.Author = value
+		case "author-mail":
+			c.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+				c.Time = time.Unix(sec, 0).UTC()
+			}
+		case "summary":
+			c.Subject = value
+		}
+		if strings.HasPrefix(key, "\t") {
+			break
+		}
+	}
+	return c, s.Err()
+}
+
+var shaRe = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// CheckRevision reports revisions that are neither a commit SHA nor a valid
+// ref name, so that values from outside, such as a release's source control
+// revision, cannot be read by git as options or revision expressions.
+func CheckRevision(rev string) error {
+	if shaRe.MatchString(rev) {
+		return nil
+	}
+	invalid := rev == "" || rev == "@" ||
+		strings.HasPrefix(rev, "-") || strings.HasPrefix(rev, "/") ||
+		strings.HasSuffix(rev, "/") || strings.HasSuffix(rev, ".") || strings.HasSuffix(rev, ".lock") ||
+		strings.Contains(rev, "..") || strings.Contains(rev, "//") || strings.Contains(rev, "@{") ||
+		strings.Contains(rev, "/.") || strings.HasPrefix(rev, ".") ||
+		strings.ContainsAny(rev, " ~^:?*[\\")
+	for _, r := range rev {
+		if r < 0x20 || r == 0x7f {
+			invalid = true
+		}
+	}
+	if invalid {
+		return fmt.Errorf("invalid revision %q: expected a commit SHA or a ref name", rev)
+	}
+	return nil
+}
+
+// LogEntry is a commit with the files it changed.
+type LogEntry struct {
+	Commit
+	Files []string `json:"files"`
+}
+
+// Log lists the commits reachable from to but not from from, newest first,
+// with the paths each one changed. Merge commits are skipped.
+func Log(ctx context.Context, dir, from, to string) ([]LogEntry, error) {
+	out, err := run(ctx, dir, "log", "--no-merges", "--name-only", "--format=%x1e%H%x1f%an%x1f%ae%x1f%at%x1f%s", "--end-of-options", from+".."+to, "--")
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out)
+}
+
+func parseLog(out []byte) ([]LogEntry, error) {
+	var entries []LogEntry
+	for _, record := range strings.Split(string(out), "\x1e") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		header, files, _ := strings.Cut(record, "\n")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("git log: unexpected line %q", header)
+		}
+		e := LogEntry{Commit: Commit{SHA: fields[0], Author: fields[1], Email: fields[2], Subject: fields[4]}}
+		if sec, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			e.Time = time.Unix(sec, 0).UTC()
+		}
+		for _, f := range strings.Split(files, "\n") {
+			if f = strings.TrimSpace(f); f != "" {
+				e.Files = append(e.Files, f)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
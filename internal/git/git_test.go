@@ -0,0 +1,189 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRepo is a throwaway repository with a fixed identity and clock.
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	when time.Time
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	r := &testRepo{t: t, dir: t.TempDir(), when: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	r.git("init", "-q")
+	return r
+}
+
+func (r *testRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", r.dir}, args...)...)
+	date := r.when.Format(time.RFC3339)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=Ada Lovelace", "GIT_AUTHOR_EMAIL=ada@example.com", "GIT_AUTHOR_DATE="+date,
+		"GIT_COMMITTER_NAME=Ada Lovelace", "GIT_COMMITTER_EMAIL=ada@example.com", "GIT_COMMITTER_DATE="+date,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes the files and commits them, returning the new SHA.
+func (r *testRepo) commit(subject string, files map[string]string) string {
+	r.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", subject)
+	r.when = r.when.Add(24 * time.Hour)
+	return r.git("rev-parse", "HEAD")
+}
+
+func TestBlame(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Add cart", map[string]string{"src/cart.go": "package cart\n\nfunc Total() int {\n\treturn 0\n}\n"})
+	sha := r.commit("Fix total", map[string]string{"src/cart.go": "package cart\n\nfunc Total() int {\n\treturn 1\n}\n"})
+
+	c, err := Blame(context.Background(), r.dir, filepath.Join("src", "cart.go"), 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.SHA != sha || c.Author != "Ada Lovelace" || c.Email != "ada@example.com" || c.Subject != "Fix total" {
+		t.Errorf("unexpected commit %+v", c)
+	}
+	if !c.Time.Equal(time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", c.Time)
+	}
+
+	if _, err := Blame(context.Background(), r.dir, "src/cart.go", 40); err == nil || !strings.HasPrefix(err.Error(), "git blame: ") {
+		t.Errorf("expected a git blame error, got %v", err)
+	}
+}
+
+func TestParseBlame(t *testing.T) {
+	out := "4f2c9e1d0a 87 87 1\n" +
+		"author Grace Hopper\n" +
+		"author-mail <grace@example.com>\n" +
+		"author-time 1714564800\n" +
+		"author-tz +0000\n" +
+		"summary Throw on empty cart\n" +
+		"filename CheckoutActivity.kt\n" +
+		"\tthrow IllegalStateException(\"Cart is empty\")\n"
+	c, err := parseBlame([]byte(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Commit{SHA: "4f2c9e1d0a", Author: "Grace Hopper", Email: "grace@example.com", Time: time.Unix(1714564800, 0).UTC(), Subject: "Throw on empty cart"}
+	if c != want {
+		t.Errorf("expected %+v, got %+v", want, c)
+	}
+
+	if _, err := parseBlame(nil); err == nil {
+		t.Error("expected an error for empty output")
+	}
+}
+
+func TestLog(t *testing.T) {
+	r := newTestRepo(t)
+	base := r.commit("Initial import", map[string]string{"src/cart.go": "package cart\n", "README.md": "# shop\n"})
+	first := r.commit("Change cart", map[string]string{"src/cart.go": "package cart // v2\n"})
+	r.git("checkout", "-q", "-b", "side")
+	side := r.commit("Touch docs", map[string]string{"README.md": "# shop v2\n"})
+	r.git("checkout", "-q", "-")
+	r.git("merge", "-q", "--no-ff", "-m", "Merge side", "side")
+	head := r.git("rev-parse", "HEAD")
+
+	entries, err := Log(context.Background(), r.dir, base, head)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected two commits without the merge, got %+v", entries)
+	}
+	bySHA := map[string]LogEntry{}
+	for _, e := range entries {
+		bySHA[e.SHA] = e
+	}
+	if e := bySHA[first]; e.Subject != "Change cart" || len(e.Files) != 1 || e.Files[0] != "src/cart.go" || e.Author != "Ada Lovelace" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e := bySHA[side]; len(e.Files) != 1 || e.Files[0] != "README.md" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	if _, err := Log(context.Background(), r.dir, "deadbeef", head); err == nil || !strings.HasPrefix(err.Error(), "git log: ") {
+		t.Errorf("expected a git log error for an unknown revision, got %v", err)
+	}
+}
+
+func TestLog_OptionLikeRevision(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Initial import", map[string]string{"README.md": "# shop\n"})
+	out := filepath.Join(t.TempDir(), "written")
+
+	if _, err := Log(context.Background(), r.dir, "--output="+out, "HEAD"); err == nil {
+		t.Error("expected an option-like revision to be rejected by git")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("expected git not to write %s, got %v", out, err)
+	}
+}
+
+func TestCheckRevision(t *testing.T) {
+	valid := []string{"4f2a9c1", "4f2a9c1e8d7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f", "v1.2.0", "main", "release/1.2", "refs/tags/v1.2.0"}
+	for _, rev := range valid {
+		if err := CheckRevision(rev); err != nil {
+			t.Errorf("%q: unexpected error: %v", rev, err)
+		}
+	}
+	invalid := []string{"", "-p", "--output=/tmp/x", "main..evil", "HEAD~1", "v1^{}", "main@{1}", "a b", "/main", "main/", "feature/.hidden", "topic.lock", "a:b", "a\nb", "@"}
+	for _, rev := range invalid {
+		if err := CheckRevision(rev); err == nil {
+			t.Errorf("%q: expected an error", rev)
+		}
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	out := "\x1eabc123\x1fGrace Hopper\x1fgrace@example.com\x1f1714564800\x1fFix cart\n\nsrc/cart.go\nsrc/util.go\n" +
+		"\x1edef456\x1fAda Lovelace\x1fada@example.com\x1f1714478400\x1fEmpty commit\n"
+	entries, err := parseLog([]byte(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	if e := entries[0]; e.SHA != "abc123" || e.Subject != "Fix cart" || len(e.Files) != 2 || !e.Time.Equal(time.Unix(1714564800, 0)) {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if entries[1].Files != nil {
+		t.Errorf("expected no files, got %v", entries[1].Files)
+	}
+
+	if _, err := parseLog([]byte("\x1enot a header\n")); err == nil {
+		t.Error("expected an error for a malformed header")
+	}
+}
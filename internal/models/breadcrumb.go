@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// BreadcrumbEntry is a breadcrumb placed on the timeline of its event.
+type BreadcrumbEntry struct {
+	Breadcrumb
+	SecondsBeforeCrash *float64 `json:"seconds_before_crash,omitempty"`
+}
+
+func (b BreadcrumbEntry) TableHeaders() []string {
+	return []string{"BEFORE_CRASH", "TYPE", "NAME", "METADATA"}
+}
+
+func (b BreadcrumbEntry) TableRow() []string {
+	offset := ""
+	if b.SecondsBeforeCrash != nil {
+		offset = fmt.Sprintf("%+.3fs", -*b.SecondsBeforeCrash)
+	}
+	meta := summarizeMetadata(b.MetaData)
+	if utf8.RuneCountInString(meta) > 60 {
+		meta = string([]rune(meta)[:57]) + "..."
+	}
+	return []string{offset, b.Type, b.Name, meta}
+}
+
+func summarizeMetadata(meta map[string]any) string {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, meta[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BreadcrumbTimeline returns the event's breadcrumbs oldest first, each with
+// its distance to the crash. The crash time is the device clock when the
+// notifier sent it, since breadcrumbs are stamped with the same clock, and
+// the time Bugsnag received the event otherwise.
+func (e Event) BreadcrumbTimeline() ([]BreadcrumbEntry, error) {
+	crumbs, err := e.ParseBreadcrumbs()
+	if err != nil {
+		return nil, err
+	}
+
+	crashAt, hasCrash := parseTimestamp(e.ReceivedAt)
+	if device, err := e.ParseDevice(); err == nil && device != nil {
+		if t, ok := parseTimestamp(device.Time); ok {
+			crashAt, hasCrash = t, true
+		}
+	}
+
+	entries := make([]BreadcrumbEntry, len(crumbs))
+	times := make([]time.Time, len(crumbs))
+	for i, c := range crumbs {
+		entries[i].Breadcrumb = c
+		t, ok := parseTimestamp(c.Timestamp)
+		if !ok {
+			continue
+		}
+		times[i] = t
+		if hasCrash {
+			secs := crashAt.Sub(t).Seconds()
+			entries[i].SecondsBeforeCrash = &secs
+		}
+	}
+
+	// Breadcrumbs without a readable timestamp keep their order, after the
+	// timed ones.
+	idx := make([]int, len(entries))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ta, tb := times[idx[a]], times[idx[b]]
+		if ta.IsZero() || tb.IsZero() {
+			return !ta.IsZero() && tb.IsZero()
+		}
+		return ta.Before(tb)
+	})
+	sorted := make([]BreadcrumbEntry, len(entries))
+	for i, j := range idx {
+		sorted[i] = entries[j]
+	}
+	return sorted, nil
+}
+
+func parseTimestamp(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
@@ -24,7 +24,7 @@ type BugsnagError struct {
 	ReleaseStages   []string `json:"release_stages,omitempty"`
 	GroupingReason   string  `json:"grouping_reason"`
 	GroupingFields   *GroupingFields `json:"grouping_fields,omitempty"`
-	Reopen          bool    `json:"reopen_rules,omitempty"`
+	ReopenRules     *ReopenRules `json:"reopen_rules,omitempty"`
 	FirstSeenUnfiltered string `json:"first_seen_unfiltered"`
 	LastSeenUnfiltered  string `json:"last_seen_unfiltered"`
 }
@@ -40,6 +40,15 @@ type ErrorOverrides struct {
 	Severity string `json:"severity,omitempty"`
 }
 
+// ReopenRules describes when a snoozed error is reopened.
+type ReopenRules struct {
+	ReopenIf              string `json:"reopen_if"`
+	Seconds               int    `json:"seconds,omitempty"`
+	Occurrences           int    `json:"occurrences,omitempty"`
+	Hours                 int    `json:"hours,omitempty"`
+	AdditionalOccurrences int    `json:"additional_occurrences,omitempty"`
+}
+
 type GroupingFields struct {
 	ErrorClass string `json:"error_class,omitempty"`
 	File       string `json:"file,omitempty"`
@@ -53,3 +62,19 @@ func (e BugsnagError) TableHeaders() []string {
 func (e BugsnagError) TableRow() []string {
 	return []string{e.ID, e.ErrorClass, e.Severity, e.Status, itoa(e.EventsCount), e.LastSeen}
 }
+
+// ErrorUpdateResult reports the outcome of a bulk operation for one error.
+type ErrorUpdateResult struct {
+	ErrorID    string `json:"error_id"`
+	ErrorClass string `json:"error_class,omitempty"`
+	Result     string `json:"result"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (r ErrorUpdateResult) TableHeaders() []string {
+	return []string{"ERROR_ID", "ERROR_CLASS", "RESULT", "ERROR"}
+}
+
+func (r ErrorUpdateResult) TableRow() []string {
+	return []string{r.ErrorID, r.ErrorClass, r.Result, r.Error}
+}
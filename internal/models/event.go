@@ -30,3 +30,32 @@ func (e Event) TableHeaders() []string {
 func (e Event) TableRow() []string {
 	return []string{e.ID, e.ErrorClass, e.Severity, e.Context, e.ReceivedAt}
 }
+
+// ParseExceptions decodes the causal chain of exceptions, outermost first.
+func (e Event) ParseExceptions() ([]Exception, error) {
+	return decodeRaw[[]Exception](e.Exceptions)
+}
+
+func (e Event) ParseThreads() ([]Thread, error) {
+	return decodeRaw[[]Thread](e.Threads)
+}
+
+func (e Event) ParseBreadcrumbs() ([]Breadcrumb, error) {
+	return decodeRaw[[]Breadcrumb](e.Breadcrumbs)
+}
+
+func (e Event) ParseApp() (*App, error) {
+	return decodeRaw[*App](e.App)
+}
+
+func (e Event) ParseDevice() (*Device, error) {
+	return decodeRaw[*Device](e.Device)
+}
+
+func (e Event) ParseUser() (*User, error) {
+	return decodeRaw[*User](e.User)
+}
+
+func (e Event) ParseRequest() (*Request, error) {
+	return decodeRaw[*Request](e.Request)
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// The typed views below decode the raw sections of an Event. Event keeps
+// the raw JSON so that output round-trips unchanged; use the Parse methods
+// to work with the typed form.
+
+type Exception struct {
+	ErrorClass string       `json:"error_class"`
+	Message    string       `json:"message"`
+	Type       string       `json:"type,omitempty"`
+	Stacktrace []StackFrame `json:"stacktrace"`
+}
+
+type StackFrame struct {
+	File          string            `json:"file"`
+	LineNumber    int               `json:"line_number"`
+	ColumnNumber  int               `json:"column_number,omitempty"`
+	Method        string            `json:"method"`
+	InProject     bool              `json:"in_project"`
+	Code          map[string]string `json:"code,omitempty"`
+	SourceControl *FrameSource      `json:"source_control,omitempty"`
+}
+
+// FrameSource links a frame to its file in the repository.
+type FrameSource struct {
+	Provider string `json:"provider,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// CodeLine is one line of the source snippet attached to a frame.
+type CodeLine struct {
+	Number int    `json:"line"`
+	Text   string `json:"text"`
+}
+
+// CodeLines returns the frame's code snippet ordered by line number.
+func (f StackFrame) CodeLines() []CodeLine {
+	lines := make([]CodeLine, 0, len(f.Code))
+	for k, text := range f.Code {
+		n, err := strconv.Atoi(k)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, CodeLine{Number: n, Text: text})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Number < lines[j].Number })
+	return lines
+}
+
+type Thread struct {
+	ID                   FlexibleString `json:"id"`
+	Name                 string         `json:"name"`
+	Type                 string         `json:"type,omitempty"`
+	State                string         `json:"state,omitempty"`
+	ErrorReportingThread bool           `json:"error_reporting_thread"`
+	Stacktrace           []StackFrame   `json:"stacktrace"`
+}
+
+type Breadcrumb struct {
+	Timestamp string         `json:"timestamp"`
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	MetaData  map[string]any `json:"meta_data,omitempty"`
+}
+
+type App struct {
+	ID                   string         `json:"id,omitempty"`
+	Version              string         `json:"version,omitempty"`
+	VersionCode          FlexibleString `json:"version_code,omitempty"`
+	BundleVersion        string         `json:"bundle_version,omitempty"`
+	ReleaseStage         string         `json:"release_stage,omitempty"`
+	Type                 string         `json:"type,omitempty"`
+	Duration             float64        `json:"duration,omitempty"`
+	DurationInForeground float64        `json:"duration_in_foreground,omitempty"`
+	InForeground         *bool          `json:"in_foreground,omitempty"`
+	BinaryArch           string         `json:"binary_arch,omitempty"`
+}
+
+type Device struct {
+	ID              string            `json:"id,omitempty"`
+	Hostname        string            `json:"hostname,omitempty"`
+	Manufacturer    string            `json:"manufacturer,omitempty"`
+	Model           string            `json:"model,omitempty"`
+	OSName          string            `json:"os_name,omitempty"`
+	OSVersion       string            `json:"os_version,omitempty"`
+	BrowserName     string            `json:"browser_name,omitempty"`
+	BrowserVersion  string            `json:"browser_version,omitempty"`
+	Locale          string            `json:"locale,omitempty"`
+	Time            string            `json:"time,omitempty"`
+	Jailbroken      bool              `json:"jailbroken,omitempty"`
+	RuntimeVersions map[string]string `json:"runtime_versions,omitempty"`
+}
+
+type User struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type Request struct {
+	URL        string            `json:"url,omitempty"`
+	HTTPMethod string            `json:"http_method,omitempty"`
+	ClientIP   string            `json:"client_ip,omitempty"`
+	Referer    string            `json:"referer,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+}
+
+// FlexibleString accepts a JSON string or number, as thread IDs can be
+// either depending on the notifier.
+type FlexibleString string
+
+func (s *FlexibleString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*s = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = FlexibleString(v)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*s = FlexibleString(n)
+	return nil
+}
+
+// decodeRaw decodes an optional raw section. A missing or null section
+// yields the zero value.
+func decodeRaw[T any](raw json.RawMessage) (T, error) {
+	var v T
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return v, nil
+	}
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
@@ -1,6 +1,13 @@
 package models
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
 
 // ---------------------------------------------------------------------------
 // Existing tests (preserved)
@@ -616,3 +623,272 @@ func TestBugsnagErrorTableRow_AllFields(t *testing.T) {
 		t.Errorf("expected last_seen '2024-12-31', got %q", row[5])
 	}
 }
+
+func TestErrorUpdateResultTable(t *testing.T) {
+	r := ErrorUpdateResult{ErrorID: "e1", ErrorClass: "TypeError", Result: "failed", Error: "API error (500)"}
+	if len(r.TableHeaders()) != len(r.TableRow()) {
+		t.Errorf("headers count %d != row count %d", len(r.TableHeaders()), len(r.TableRow()))
+	}
+	row := r.TableRow()
+	if row[2] != "failed" || row[3] != "API error (500)" {
+		t.Errorf("unexpected row: %v", row)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Event details (fixtures in testdata/)
+// ---------------------------------------------------------------------------
+
+func loadEventFixture(t *testing.T, name string) (Event, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+	return e, data
+}
+
+func TestEvent_ParseExceptions(t *testing.T) {
+	e, _ := loadEventFixture(t, "event_android.json")
+	exceptions, err := e.ParseExceptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exceptions) != 2 {
+		t.Fatalf("expected 2 exceptions in the causal chain, got %d", len(exceptions))
+	}
+	if exceptions[1].ErrorClass != "java.util.NoSuchElementException" {
+		t.Errorf("unexpected cause class %q", exceptions[1].ErrorClass)
+	}
+
+	top := exceptions[0].Stacktrace[0]
+	if top.File != "CheckoutActivity.kt" || top.LineNumber != 87 || !top.InProject {
+		t.Errorf("unexpected top frame: %+v", top)
+	}
+	if top.Method != "com.acme.shop.CheckoutActivity.submitOrder" {
+		t.Errorf("unexpected method %q", top.Method)
+	}
+	if top.SourceControl == nil || top.SourceControl.Provider != "github" {
+		t.Errorf("expected github source control, got %+v", top.SourceControl)
+	}
+	if exceptions[0].Stacktrace[1].InProject {
+		t.Error("expected the framework frame not to be in project")
+	}
+	if got := exceptions[1].Stacktrace[0].ColumnNumber; got != 12 {
+		t.Errorf("expected column 12, got %d", got)
+	}
+
+	lines := top.CodeLines()
+	if len(lines) != 4 || lines[0].Number != 85 || lines[3].Number != 88 {
+		t.Fatalf("expected code lines 85-88 in order, got %+v", lines)
+	}
+	if !strings.Contains(lines[2].Text, "throw IllegalStateException") {
+		t.Errorf("unexpected line 87: %q", lines[2].Text)
+	}
+}
+
+func TestEvent_ParseThreads(t *testing.T) {
+	e, _ := loadEventFixture(t, "event_android.json")
+	threads, err := e.ParseThreads()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+	if threads[0].ID != "2" || threads[1].ID != "17" {
+		t.Errorf("expected numeric and string thread IDs to decode, got %q and %q", threads[0].ID, threads[1].ID)
+	}
+	if !threads[0].ErrorReportingThread || threads[0].State != "RUNNABLE" {
+		t.Errorf("unexpected main thread: %+v", threads[0])
+	}
+
+	browser, _ := loadEventFixture(t, "event_browser.json")
+	threads, err = browser.ParseThreads()
+	if err != nil || threads != nil {
+		t.Errorf("expected null threads to decode to nil, got %v (%v)", threads, err)
+	}
+}
+
+func TestEvent_ParseBreadcrumbs(t *testing.T) {
+	e, _ := loadEventFixture(t, "event_browser.json")
+	crumbs, err := e.ParseBreadcrumbs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crumbs) != 3 {
+		t.Fatalf("expected 3 breadcrumbs, got %d", len(crumbs))
+	}
+	if crumbs[1].Type != "user" || crumbs[1].MetaData["targetText"] != "Checkout" {
+		t.Errorf("unexpected breadcrumb: %+v", crumbs[1])
+	}
+	if crumbs[0].MetaData["status"] != float64(201) {
+		t.Errorf("expected numeric metadata, got %v", crumbs[0].MetaData["status"])
+	}
+}
+
+func TestEvent_ParseAppDeviceUserRequest(t *testing.T) {
+	e, _ := loadEventFixture(t, "event_android.json")
+
+	app, err := e.ParseApp()
+	if err != nil || app == nil {
+		t.Fatalf("unexpected app: %v (%v)", app, err)
+	}
+	if app.Version != "3.14.0" || app.VersionCode != "31400" || app.ReleaseStage != "production" {
+		t.Errorf("unexpected app: %+v", app)
+	}
+	if app.InForeground == nil || !*app.InForeground {
+		t.Error("expected in_foreground=true")
+	}
+
+	device, err := e.ParseDevice()
+	if err != nil || device.Model != "Pixel 7" || device.RuntimeVersions["androidApiLevel"] != "34" {
+		t.Errorf("unexpected device: %+v (%v)", device, err)
+	}
+
+	user, err := e.ParseUser()
+	if err != nil || user.Email != "ada@example.com" {
+		t.Errorf("unexpected user: %+v (%v)", user, err)
+	}
+
+	request, err := e.ParseRequest()
+	if err != nil || request != nil {
+		t.Errorf("expected no request section, got %+v (%v)", request, err)
+	}
+
+	browser, _ := loadEventFixture(t, "event_browser.json")
+	request, err = browser.ParseRequest()
+	if err != nil || request.HTTPMethod != "GET" || request.Headers["User-Agent"] == "" {
+		t.Errorf("unexpected request: %+v (%v)", request, err)
+	}
+}
+
+func TestEvent_ParseInvalidSection(t *testing.T) {
+	e := Event{Exceptions: json.RawMessage(`{"not": "a list"}`)}
+	if _, err := e.ParseExceptions(); err == nil {
+		t.Error("expected an error for a malformed exceptions section")
+	}
+}
+
+func TestEvent_RawSectionsRoundTrip(t *testing.T) {
+	for _, name := range []string{"event_android.json", "event_browser.json"} {
+		e, data := loadEventFixture(t, name)
+		out, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		var want, got map[string]any
+		_ = json.Unmarshal(data, &want)
+		_ = json.Unmarshal(out, &got)
+		for _, key := range []string{"exceptions", "threads", "breadcrumbs", "app", "device", "user", "request", "meta_data"} {
+			w, _ := json.Marshal(want[key])
+			g, _ := json.Marshal(got[key])
+			if string(w) != string(g) {
+				t.Errorf("%s: %s changed on round trip:\nwant %s\ngot  %s", name, key, w, g)
+			}
+		}
+	}
+}
+
+func TestEvent_BreadcrumbTimeline(t *testing.T) {
+	e, _ := loadEventFixture(t, "event_browser.json")
+	timeline, err := e.BreadcrumbTimeline()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, b := range timeline {
+		names = append(names, b.Name)
+	}
+	if strings.Join(names, "|") != "UI click|XMLHttpRequest succeeded|Console output" {
+		t.Errorf("expected breadcrumbs sorted by timestamp, got %v", names)
+	}
+
+	// Offsets are measured against device.time (15:41:06.998).
+	if got := *timeline[0].SecondsBeforeCrash; got < 8.667 || got > 8.669 {
+		t.Errorf("expected ~8.668s before crash, got %v", got)
+	}
+	row := timeline[0].TableRow()
+	if row[0] != "-8.668s" || row[1] != "user" || row[3] != "targetSelector=BUTTON#checkout, targetText=Checkout" {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if len(row) != len(timeline[0].TableHeaders()) {
+		t.Error("headers and row length differ")
+	}
+}
+
+func TestEvent_BreadcrumbTimelineWithoutDeviceTime(t *testing.T) {
+	e := Event{
+		ReceivedAt: "2024-05-24T10:00:10Z",
+		Breadcrumbs: json.RawMessage(`[
+			{"timestamp": "not a time", "name": "untimed", "type": "manual"},
+			{"timestamp": "2024-05-24T10:00:07.5Z", "name": "late", "type": "log"},
+			{"timestamp": "2024-05-24T10:00:00Z", "name": "early", "type": "state"}
+		]`),
+	}
+	timeline, err := e.BreadcrumbTimeline()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeline[0].Name != "early" || timeline[1].Name != "late" || timeline[2].Name != "untimed" {
+		t.Fatalf("unexpected order: %+v", timeline)
+	}
+	if *timeline[1].SecondsBeforeCrash != 2.5 {
+		t.Errorf("expected 2.5s before received_at, got %v", *timeline[1].SecondsBeforeCrash)
+	}
+	if timeline[2].SecondsBeforeCrash != nil || timeline[2].TableRow()[0] != "" {
+		t.Error("expected no offset for an untimed breadcrumb")
+	}
+}
+
+func TestBreadcrumbEntry_TableRowTruncatesRunes(t *testing.T) {
+	b := BreadcrumbEntry{Breadcrumb: Breadcrumb{MetaData: map[string]any{"message": strings.Repeat("é", 70)}}}
+	meta := b.TableRow()[3]
+	if !utf8.ValidString(meta) {
+		t.Fatalf("expected valid UTF-8, got %q", meta)
+	}
+	if n := utf8.RuneCountInString(meta); n != 60 || !strings.HasSuffix(meta, "...") {
+		t.Errorf("expected 57 characters and an ellipsis, got %d: %q", n, meta)
+	}
+
+	b.MetaData = map[string]any{"message": strings.Repeat("é", 52)}
+	if meta := b.TableRow()[3]; meta != "message="+strings.Repeat("é", 52) {
+		t.Errorf("expected 60 characters to be kept, got %q", meta)
+	}
+}
+
+func TestBreadcrumbEntry_TableRowOffsetSign(t *testing.T) {
+	before, after := 1.5, -0.5
+	if got := (BreadcrumbEntry{SecondsBeforeCrash: &before}).TableRow()[0]; got != "-1.500s" {
+		t.Errorf("expected -1.500s before the crash, got %q", got)
+	}
+	if got := (BreadcrumbEntry{SecondsBeforeCrash: &after}).TableRow()[0]; got != "+0.500s" {
+		t.Errorf("expected +0.500s after the crash, got %q", got)
+	}
+}
+
+func TestSymbolicatedFrame_TableRow(t *testing.T) {
+	resolved := SymbolicatedFrame{
+		File: "https://shop.acme.test/app.js", LineNumber: 1, ColumnNumber: 48213, Method: "t",
+		Original: &OriginalPosition{File: "src/Summary.jsx", Line: 12, Column: 5, Name: "total"},
+	}
+	row := resolved.TableRow()
+	if row[1] != "src/Summary.jsx:12:5" || row[2] != "total" || row[3] != "https://shop.acme.test/app.js:1:48213" {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if len(row) != len(resolved.TableHeaders()) {
+		t.Error("headers and row length differ")
+	}
+
+	unresolved := SymbolicatedFrame{Exception: 1, File: "vendor.js", LineNumber: 2, ColumnNumber: 7, Method: "commitRoot", Error: "no source map found"}
+	row = unresolved.TableRow()
+	if row[0] != "1" || row[1] != "(no source map found)" || row[2] != "commitRoot" {
+		t.Errorf("unexpected row: %v", row)
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "strings"
+
+// ErrorOwnership gives the owners of an error according to CODEOWNERS, from
+// the top in-project frame of its latest event. Path is the frame's file
+// relative to the repository root.
+type ErrorOwnership struct {
+	ErrorID    string   `json:"error_id"`
+	ErrorClass string   `json:"error_class"`
+	File       string   `json:"file,omitempty"`
+	Path       string   `json:"path,omitempty"`
+	Owners     []string `json:"owners"`
+	Rules      []string `json:"rules,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func (o ErrorOwnership) TableHeaders() []string {
+	return []string{"ERROR_ID", "ERROR_CLASS", "OWNERS", "PATH"}
+}
+
+func (o ErrorOwnership) TableRow() []string {
+	path := o.Path
+	if o.Error != "" {
+		path = "(" + o.Error + ")"
+	}
+	return []string{o.ErrorID, o.ErrorClass, strings.Join(o.Owners, " "), path}
+}
@@ -1,20 +1,22 @@
 package models
 
 type Release struct {
-	ID             string `json:"id"`
-	ProjectID      string `json:"project_id"`
-	Version        string `json:"app_version"`
-	ReleaseStage   ReleaseStage `json:"release_stage"`
-	BuilderName    string `json:"builder_name"`
-	ReleaseSource  string `json:"release_source"`
-	ReleaseTime    string `json:"release_time"`
-	BuildLabel     string `json:"build_label"`
-	Metadata       map[string]string `json:"metadata,omitempty"`
-	SourceControl  *SourceControl    `json:"source_control,omitempty"`
-	TotalSessionsCount     int `json:"total_sessions_count"`
-	UnhandledSessionsCount int `json:"unhandled_sessions_count"`
-	ErrorsIntroducedCount  int `json:"errors_introduced_count"`
-	ErrorsSeenCount        int `json:"errors_seen_count"`
+	ID                                  string            `json:"id"`
+	ProjectID                           string            `json:"project_id"`
+	Version                             string            `json:"app_version"`
+	ReleaseStage                        ReleaseStage      `json:"release_stage"`
+	BuilderName                         string            `json:"builder_name"`
+	ReleaseSource                       string            `json:"release_source"`
+	ReleaseTime                         string            `json:"release_time"`
+	BuildLabel                          string            `json:"build_label"`
+	Metadata                            map[string]string `json:"metadata,omitempty"`
+	SourceControl                       *SourceControl    `json:"source_control,omitempty"`
+	TotalSessionsCount                  int               `json:"total_sessions_count"`
+	UnhandledSessionsCount              int               `json:"unhandled_sessions_count"`
+	ErrorsIntroducedCount               int               `json:"errors_introduced_count"`
+	ErrorsSeenCount                     int               `json:"errors_seen_count"`
+	AccumulativeDailyUsersSeen          int               `json:"accumulative_daily_users_seen"`
+	AccumulativeDailyUsersWithUnhandled int               `json:"accumulative_daily_users_with_unhandled"`
 }
 
 type ReleaseStage struct {
@@ -35,3 +37,35 @@ func (r Release) TableHeaders() []string {
 func (r Release) TableRow() []string {
 	return []string{r.ID, r.Version, r.ReleaseStage.Name, r.ReleaseSource, r.ReleaseTime}
 }
+
+// ReleaseGroup gathers the releases of one app version in one release stage.
+type ReleaseGroup struct {
+	ID                                  string         `json:"id"`
+	ProjectID                           string         `json:"project_id"`
+	ReleaseStageName                    string         `json:"release_stage_name"`
+	AppVersion                          string         `json:"app_version"`
+	FirstReleasedAt                     string         `json:"first_released_at"`
+	FirstReleaseID                      string         `json:"first_release_id"`
+	ReleasesCount                       int            `json:"releases_count"`
+	HasSecondaryVersions                bool           `json:"has_secondary_versions"`
+	BuildTool                           string         `json:"build_tool"`
+	BuilderName                         string         `json:"builder_name"`
+	SourceControl                       *SourceControl `json:"source_control,omitempty"`
+	TopReleaseGroup                     bool           `json:"top_release_group"`
+	TotalSessionsCount                  int            `json:"total_sessions_count"`
+	UnhandledSessionsCount              int            `json:"unhandled_sessions_count"`
+	AccumulativeDailyUsersSeen          int            `json:"accumulative_daily_users_seen"`
+	AccumulativeDailyUsersWithUnhandled int            `json:"accumulative_daily_users_with_unhandled"`
+}
+
+func (g ReleaseGroup) TableHeaders() []string {
+	return []string{"ID", "VERSION", "RELEASE_STAGE", "RELEASES", "FIRST_RELEASED_AT", "SESSIONS", "UNHANDLED", "TOP"}
+}
+
+func (g ReleaseGroup) TableRow() []string {
+	top := ""
+	if g.TopReleaseGroup {
+		top = "yes"
+	}
+	return []string{g.ID, g.AppVersion, g.ReleaseStageName, itoa(g.ReleasesCount), g.FirstReleasedAt, itoa(g.TotalSessionsCount), itoa(g.UnhandledSessionsCount), top}
+}
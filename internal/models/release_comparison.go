@@ -0,0 +1,106 @@
+package models
+
+import "fmt"
+
+// ReleaseComparison reports how errors and stability changed between two
+// versions of an app.
+type ReleaseComparison struct {
+	From ReleaseSummary `json:"from"`
+	To   ReleaseSummary `json:"to"`
+	// CrashRateChange is To.CrashRate minus From.CrashRate, in percentage
+	// points. It is null when either version has no sessions.
+	CrashRateChange *float64             `json:"crash_rate_change"`
+	NewErrors       []ReleaseErrorChange `json:"new_errors"`
+	RegressedErrors []ReleaseErrorChange `json:"regressed_errors"`
+	ResolvedErrors  []ReleaseErrorChange `json:"resolved_errors"`
+}
+
+// ReleaseSummary aggregates the releases of one version, across release
+// stages unless the comparison was limited to one. CrashRate is the
+// percentage of sessions with an unhandled error, null without sessions.
+type ReleaseSummary struct {
+	Version           string   `json:"version"`
+	ReleaseStages     []string `json:"release_stages"`
+	ReleaseTime       string   `json:"release_time"`
+	TotalSessions     int      `json:"total_sessions"`
+	UnhandledSessions int      `json:"unhandled_sessions"`
+	CrashRate         *float64 `json:"crash_rate"`
+	CrashFreeRate     *float64 `json:"crash_free_rate"`
+	ErrorsSeen        int      `json:"errors_seen"`
+}
+
+// ReleaseErrorChange is an error that appeared, came back or stopped between
+// two versions. Change is "new", "regressed" or "resolved".
+type ReleaseErrorChange struct {
+	Change     string `json:"change"`
+	ID         string `json:"id"`
+	ErrorClass string `json:"error_class"`
+	Message    string `json:"message"`
+	Severity   string `json:"severity"`
+	Events     int    `json:"events"`
+	FirstSeen  string `json:"first_seen"`
+	URL        string `json:"url"`
+}
+
+func (e ReleaseErrorChange) TableHeaders() []string {
+	return []string{"CHANGE", "ERROR_ID", "ERROR_CLASS", "EVENTS", "MESSAGE"}
+}
+
+func (e ReleaseErrorChange) TableRow() []string {
+	msg := e.Message
+	if len(msg) > 60 {
+		msg = msg[:57] + "..."
+	}
+	return []string{e.Change, e.ID, e.ErrorClass, itoa(e.Events), msg}
+}
+
+// ReleaseMetric is one line of the side-by-side view of a comparison.
+type ReleaseMetric struct {
+	Metric string `json:"metric"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Change string `json:"change"`
+}
+
+func (m ReleaseMetric) TableHeaders() []string {
+	return []string{"METRIC", "FROM", "TO", "CHANGE"}
+}
+
+func (m ReleaseMetric) TableRow() []string {
+	return []string{m.Metric, m.From, m.To, m.Change}
+}
+
+// Metrics lists the comparison's figures side by side.
+func (c ReleaseComparison) Metrics() []ReleaseMetric {
+	pct := func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", *v)
+	}
+	change := "-"
+	if c.CrashRateChange != nil {
+		change = fmt.Sprintf("%+.2f pts", *c.CrashRateChange)
+	}
+	delta := func(from, to int) string { return fmt.Sprintf("%+d", to-from) }
+
+	return []ReleaseMetric{
+		{"version", c.From.Version, c.To.Version, ""},
+		{"sessions", itoa(c.From.TotalSessions), itoa(c.To.TotalSessions), delta(c.From.TotalSessions, c.To.TotalSessions)},
+		{"unhandled_sessions", itoa(c.From.UnhandledSessions), itoa(c.To.UnhandledSessions), delta(c.From.UnhandledSessions, c.To.UnhandledSessions)},
+		{"crash_rate", pct(c.From.CrashRate), pct(c.To.CrashRate), change},
+		{"crash_free_rate", pct(c.From.CrashFreeRate), pct(c.To.CrashFreeRate), ""},
+		{"errors_seen", itoa(c.From.ErrorsSeen), itoa(c.To.ErrorsSeen), delta(c.From.ErrorsSeen, c.To.ErrorsSeen)},
+		{"new_errors", "", itoa(len(c.NewErrors)), ""},
+		{"regressed_errors", "", itoa(len(c.RegressedErrors)), ""},
+		{"resolved_errors", "", itoa(len(c.ResolvedErrors)), ""},
+	}
+}
+
+// Changes lists the new, regressed and resolved errors in that order.
+func (c ReleaseComparison) Changes() []ReleaseErrorChange {
+	var all []ReleaseErrorChange
+	all = append(all, c.NewErrors...)
+	all = append(all, c.RegressedErrors...)
+	return append(all, c.ResolvedErrors...)
+}
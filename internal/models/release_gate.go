@@ -0,0 +1,39 @@
+package models
+
+import "fmt"
+
+// ReleaseGate is the verdict of the health checks run on a release. Passed
+// is false when any check failed.
+type ReleaseGate struct {
+	Version       string               `json:"version"`
+	ReleaseStages []string             `json:"release_stages"`
+	Passed        bool                 `json:"passed"`
+	Checks        []GateCheck          `json:"checks"`
+	NewErrors     []ReleaseErrorChange `json:"new_errors"`
+}
+
+// GateCheck is one threshold of a release gate. Actual is null when the
+// value could not be measured, such as a crash-free rate without sessions.
+type GateCheck struct {
+	Name      string   `json:"name"`
+	Passed    bool     `json:"passed"`
+	Actual    *float64 `json:"actual"`
+	Threshold float64  `json:"threshold"`
+	Message   string   `json:"message"`
+}
+
+func (c GateCheck) TableHeaders() []string {
+	return []string{"CHECK", "RESULT", "ACTUAL", "THRESHOLD", "MESSAGE"}
+}
+
+func (c GateCheck) TableRow() []string {
+	result := "FAIL"
+	if c.Passed {
+		result = "PASS"
+	}
+	actual := "-"
+	if c.Actual != nil {
+		actual = fmt.Sprintf("%g", *c.Actual)
+	}
+	return []string{c.Name, result, actual, fmt.Sprintf("%g", c.Threshold), c.Message}
+}
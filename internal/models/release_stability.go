@@ -0,0 +1,53 @@
+package models
+
+import "fmt"
+
+// ReleaseStability is the crash-free rates of one release. Rates are
+// percentages, null when the release has no sessions (or no users).
+type ReleaseStability struct {
+	// Rank orders releases from the most stable, by crash-free session
+	// rate; releases without sessions are not ranked and have rank 0.
+	Rank                 int      `json:"rank"`
+	ReleaseID            string   `json:"release_id"`
+	Version              string   `json:"version"`
+	ReleaseStage         string   `json:"release_stage"`
+	ReleaseTime          string   `json:"release_time"`
+	TotalSessions        int      `json:"total_sessions"`
+	UnhandledSessions    int      `json:"unhandled_sessions"`
+	CrashFreeSessionRate *float64 `json:"crash_free_session_rate"`
+	UsersSeen            int      `json:"users_seen"`
+	UsersWithUnhandled   int      `json:"users_with_unhandled"`
+	CrashFreeUserRate    *float64 `json:"crash_free_user_rate"`
+	// BelowTarget is set when a target was given and the crash-free session
+	// rate is under it, or unknown.
+	BelowTarget bool `json:"below_target"`
+	// BaselineDelta is the crash-free session rate minus the baseline's in
+	// the same release stage, in percentage points.
+	BaselineDelta *float64 `json:"baseline_delta,omitempty"`
+}
+
+func (r ReleaseStability) TableHeaders() []string {
+	return []string{"RANK", "VERSION", "RELEASE_STAGE", "SESSIONS", "CRASH_FREE_SESSIONS", "CRASH_FREE_USERS", "BASELINE_DELTA", "BELOW_TARGET"}
+}
+
+func (r ReleaseStability) TableRow() []string {
+	rank := "-"
+	if r.Rank > 0 {
+		rank = itoa(r.Rank)
+	}
+	pct := func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", *v)
+	}
+	delta := "-"
+	if r.BaselineDelta != nil {
+		delta = fmt.Sprintf("%+.2f pts", *r.BaselineDelta)
+	}
+	below := ""
+	if r.BelowTarget {
+		below = "yes"
+	}
+	return []string{rank, r.Version, r.ReleaseStage, itoa(r.TotalSessions), pct(r.CrashFreeSessionRate), pct(r.CrashFreeUserRate), delta, below}
+}
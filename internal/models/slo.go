@@ -0,0 +1,67 @@
+package models
+
+import "fmt"
+
+// SLOStatus is the state of a crash-free sessions objective over a rolling
+// window. Rates are percentages and are null without sessions.
+type SLOStatus struct {
+	Name         string `json:"name"`
+	ProjectID    string `json:"project_id"`
+	ReleaseStage string `json:"release_stage"`
+	// Target is the crash-free sessions percentage aimed for, e.g. 99.8.
+	Target      float64 `json:"target"`
+	Window      string  `json:"window"`
+	WindowStart string  `json:"window_start"`
+	WindowEnd   string  `json:"window_end"`
+
+	TotalSessions     int      `json:"total_sessions"`
+	UnhandledSessions int      `json:"unhandled_sessions"`
+	CrashFreeRate     *float64 `json:"crash_free_rate"`
+	// ErrorBudget is the number of sessions with an unhandled error the
+	// target allows over the window.
+	ErrorBudget     float64  `json:"error_budget"`
+	BudgetConsumed  *float64 `json:"budget_consumed"`
+	BudgetRemaining *float64 `json:"budget_remaining"`
+	Exhausted       bool     `json:"exhausted"`
+
+	// BurnRates is how fast the budget is being spent over the short and
+	// long alerting windows: 1 spends exactly the budget over the SLO window.
+	BurnRates []BurnRate `json:"burn_rates"`
+	// BurnAlert is set when every burn rate is above the threshold.
+	BurnAlert bool `json:"burn_alert"`
+
+	// Status sums up the above: ok, burning, exhausted or error.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BurnRate is the error budget burn rate over one alerting window.
+type BurnRate struct {
+	Window string   `json:"window"`
+	Rate   *float64 `json:"rate"`
+}
+
+func (s SLOStatus) TableHeaders() []string {
+	return []string{"NAME", "TARGET", "WINDOW", "CRASH_FREE", "BUDGET_REMAINING", "BURN_RATES", "STATUS"}
+}
+
+func (s SLOStatus) TableRow() []string {
+	pct := func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", *v)
+	}
+	var burn string
+	for i, b := range s.BurnRates {
+		if i > 0 {
+			burn += " "
+		}
+		if b.Rate == nil {
+			burn += b.Window + ":-"
+		} else {
+			burn += fmt.Sprintf("%s:%.2f", b.Window, *b.Rate)
+		}
+	}
+	return []string{s.Name, fmt.Sprintf("%g%%", s.Target), s.Window, pct(s.CrashFreeRate), pct(s.BudgetRemaining), burn, s.Status}
+}
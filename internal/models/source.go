@@ -0,0 +1,30 @@
+package models
+
+// EventWithSource is an event with its in-project frames matched to files in
+// the local checkout.
+type EventWithSource struct {
+	*Event
+	SourceFrames []SourceFrame `json:"source_frames"`
+}
+
+// SourceFrame is an in-project stack frame resolved against the local
+// checkout. Path is empty and Error set when the file could not be found.
+type SourceFrame struct {
+	Exception  int        `json:"exception"`
+	File       string     `json:"file"`
+	LineNumber int        `json:"line_number"`
+	Method     string     `json:"method"`
+	Path       string     `json:"path,omitempty"`
+	Code       []CodeLine `json:"code,omitempty"`
+	Blame      *Blame     `json:"blame,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Blame is the last commit that changed a line.
+type Blame struct {
+	Commit  string `json:"commit"`
+	Author  string `json:"author"`
+	Email   string `json:"email,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Summary string `json:"summary"`
+}
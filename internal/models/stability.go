@@ -24,3 +24,11 @@ func (t TimelinePoint) TableHeaders() []string {
 func (t TimelinePoint) TableRow() []string {
 	return []string{t.BucketStart, t.BucketEnd, itoa(t.TotalSessionsCount), itoa(t.UnhandledSessionsCount), ftoa(t.UnhandledRate)}
 }
+
+func (t TimelinePoint) ChartSeries() string {
+	return "unhandled_rate"
+}
+
+func (t TimelinePoint) ChartPoint() (string, float64) {
+	return t.BucketStart, t.UnhandledRate
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuspectCommit is a commit shipped in the release that introduced an error
+// which changed files from the error's stack trace. Score weighs each
+// matched file by how close to the top of the trace it appears.
+type SuspectCommit struct {
+	SHA          string   `json:"sha"`
+	Author       string   `json:"author"`
+	Email        string   `json:"email,omitempty"`
+	Date         string   `json:"date"`
+	Subject      string   `json:"subject"`
+	MatchedFiles []string `json:"matched_files"`
+	Score        float64  `json:"score"`
+}
+
+func (s SuspectCommit) TableHeaders() []string {
+	return []string{"SCORE", "SHA", "AUTHOR", "DATE", "SUBJECT", "MATCHED_FILES"}
+}
+
+func (s SuspectCommit) TableRow() []string {
+	sha := s.SHA
+	if len(sha) > 8 {
+		sha = sha[:8]
+	}
+	return []string{fmt.Sprintf("%.2f", s.Score), sha, s.Author, s.Date, s.Subject, strings.Join(s.MatchedFiles, ", ")}
+}
@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+// SymbolicatedFrame is a minified stack frame of an event together with the
+// original position a source map resolved it to.
+type SymbolicatedFrame struct {
+	Exception    int               `json:"exception"`
+	File         string            `json:"file"`
+	LineNumber   int               `json:"line_number"`
+	ColumnNumber int               `json:"column_number,omitempty"`
+	Method       string            `json:"method"`
+	Original     *OriginalPosition `json:"original,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// OriginalPosition is a location in the original, unminified source.
+type OriginalPosition struct {
+	File   string     `json:"file"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+	Name   string     `json:"name,omitempty"`
+	Code   []CodeLine `json:"code,omitempty"`
+}
+
+func (f SymbolicatedFrame) TableHeaders() []string {
+	return []string{"EXCEPTION", "ORIGINAL", "NAME", "MINIFIED"}
+}
+
+func (f SymbolicatedFrame) TableRow() []string {
+	minified := fmt.Sprintf("%s:%d:%d", f.File, f.LineNumber, f.ColumnNumber)
+	if f.Original == nil {
+		return []string{fmt.Sprint(f.Exception), "(" + f.Error + ")", f.Method, minified}
+	}
+	original := fmt.Sprintf("%s:%d:%d", f.Original.File, f.Original.Line, f.Original.Column)
+	name := f.Original.Name
+	if name == "" {
+		name = f.Method
+	}
+	return []string{fmt.Sprint(f.Exception), original, name, minified}
+}
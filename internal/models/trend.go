@@ -13,3 +13,11 @@ func (t TrendBucket) TableHeaders() []string {
 func (t TrendBucket) TableRow() []string {
 	return []string{t.From, t.To, itoa(t.EventsCount)}
 }
+
+func (t TrendBucket) ChartSeries() string {
+	return "events_count"
+}
+
+func (t TrendBucket) ChartPoint() (string, float64) {
+	return t.From, float64(t.EventsCount)
+}
@@ -0,0 +1,29 @@
+package models
+
+import "fmt"
+
+// TrendAnomaly is a trend bucket whose events count is well above the
+// baseline of the buckets before it, for the whole project or one error.
+type TrendAnomaly struct {
+	// Scope is "project" or "error".
+	Scope       string `json:"scope"`
+	ErrorID     string `json:"error_id,omitempty"`
+	ErrorClass  string `json:"error_class,omitempty"`
+	Message     string `json:"message,omitempty"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	EventsCount int    `json:"events_count"`
+	// Baseline is the events count expected from the previous buckets.
+	Baseline float64 `json:"baseline"`
+	// Score is the number of deviations the events count is above the
+	// baseline.
+	Score float64 `json:"score"`
+}
+
+func (a TrendAnomaly) TableHeaders() []string {
+	return []string{"SCOPE", "ERROR_ID", "ERROR_CLASS", "FROM", "TO", "EVENTS", "BASELINE", "SCORE"}
+}
+
+func (a TrendAnomaly) TableRow() []string {
+	return []string{a.Scope, a.ErrorID, a.ErrorClass, a.From, a.To, itoa(a.EventsCount), fmt.Sprintf("%.1f", a.Baseline), fmt.Sprintf("%.1f", a.Score)}
+}
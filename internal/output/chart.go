@@ -0,0 +1,276 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Charter is implemented by the list items the chart format can draw: one
+// value per time bucket, labelled with the start of the bucket.
+type Charter interface {
+	// ChartSeries names the value drawn, such as "events_count".
+	ChartSeries() string
+	ChartPoint() (label string, value float64)
+}
+
+// Chart styles, selected with "chart" or "chart=sparkline".
+const (
+	ChartBars      = "bars"
+	ChartSparkline = "sparkline"
+)
+
+const (
+	chartHeight  = 8
+	defaultWidth = 80
+)
+
+// chartGlyphs are the characters a chart is drawn with.
+type chartGlyphs struct {
+	// levels fill a cell from empty to full.
+	levels []rune
+	axis   string
+	tick   string
+	corner string
+	rule   string
+}
+
+var (
+	unicodeGlyphs = chartGlyphs{levels: []rune(" ▁▂▃▄▅▆▇█"), axis: "│", tick: "┤", corner: "└", rule: "─"}
+	asciiGlyphs   = chartGlyphs{levels: []rune(" .:-=+*#"), axis: "|", tick: "+", corner: "+", rule: "-"}
+)
+
+// terminalWidth returns the COLUMNS environment variable when set, or the
+// width of the terminal f is attached to, or 0 when unknown.
+func terminalWidth(f *os.File) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return terminalColumns(f)
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// chart is a series of values ready to be drawn.
+type chart struct {
+	series string
+	labels []string
+	values []float64
+}
+
+// printChart draws a list of Charter items.
+func (p *Printer) printChart(data any) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("the chart format is not supported for %T", data)
+	}
+	var c chart
+	for i := 0; i < v.Len(); i++ {
+		item, ok := v.Index(i).Interface().(Charter)
+		if !ok {
+			return fmt.Errorf("the chart format is not supported for %T", v.Index(i).Interface())
+		}
+		label, value := item.ChartPoint()
+		c.series = item.ChartSeries()
+		c.labels = append(c.labels, label)
+		c.values = append(c.values, value)
+	}
+	if len(c.values) == 0 {
+		_, err := fmt.Fprintln(p.Out, "No results found.")
+		return err
+	}
+
+	width := p.Width
+	if width <= 0 {
+		width = defaultWidth
+	}
+	glyphs := asciiGlyphs
+	if p.Unicode {
+		glyphs = unicodeGlyphs
+	}
+	switch p.ChartStyle {
+	case "", ChartBars:
+		return writeBarChart(p.Out, c, width, glyphs)
+	case ChartSparkline:
+		return writeSparkline(p.Out, c, width, glyphs)
+	}
+	return fmt.Errorf("unknown chart style %q: expected %s or %s", p.ChartStyle, ChartBars, ChartSparkline)
+}
+
+// writeBarChart draws one vertical bar per value, or per group of values
+// when there are more than the width allows, from a zero baseline.
+func writeBarChart(w io.Writer, c chart, width int, g chartGlyphs) error {
+	top := axisMax(c.values)
+	yLabels := map[int]string{
+		chartHeight - 1: formatChartValue(top),
+		chartHeight / 2: formatChartValue(top / 2),
+		0:               formatChartValue(0),
+	}
+	labelWidth := 0
+	for _, l := range yLabels {
+		labelWidth = max(labelWidth, len(l))
+	}
+
+	columns, perColumn := downsample(c.values, max(width-labelWidth-2, 1))
+	barWidth := 1
+	if len(columns) > 0 {
+		barWidth = min(max((width-labelWidth-2)/len(columns), 1), 4)
+	}
+	plotWidth := len(columns) * barWidth
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s, %d buckets", c.series, len(c.values))
+	if perColumn > 1 {
+		fmt.Fprintf(&b, " (highest of every %d per bar)", perColumn)
+	}
+	b.WriteString("\n")
+
+	for row := chartHeight - 1; row >= 0; row-- {
+		axis := g.axis
+		label, ok := yLabels[row]
+		if ok {
+			axis = g.tick
+		}
+		line := fmt.Sprintf("%*s %s", labelWidth, label, axis)
+		for _, v := range columns {
+			cell := barCell(v, top, row, g)
+			line += strings.Repeat(string(cell), max(barWidth-1, 1))
+			if barWidth > 1 {
+				line += " "
+			}
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	fmt.Fprintf(&b, "%*s %s%s\n", labelWidth, "", g.corner, strings.Repeat(g.rule, plotWidth))
+	b.WriteString(xLabels(c.labels, labelWidth+2, plotWidth, width))
+	b.WriteString(chartStats(c))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// barCell returns the character of a bar of value v at row, counted from
+// the bottom. Non-zero values always show at least the lowest level.
+func barCell(v, top float64, row int, g chartGlyphs) rune {
+	steps := len(g.levels) - 1
+	fill := v/top*chartHeight - float64(row)
+	level := int(math.Round(min(max(fill, 0), 1) * float64(steps)))
+	if row == 0 && v > 0 && level == 0 {
+		level = 1
+	}
+	return g.levels[level]
+}
+
+// writeSparkline draws the values on a single line followed by the stats.
+func writeSparkline(w io.Writer, c chart, width int, g chartGlyphs) error {
+	prefix := c.series + " "
+	columns, perColumn := downsample(c.values, max(width-len(prefix), 1))
+	top := axisMax(c.values)
+	steps := len(g.levels) - 1
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, v := range columns {
+		level := int(math.Round(min(max(v/top, 0), 1) * float64(steps)))
+		if v > 0 && level == 0 {
+			level = 1
+		}
+		b.WriteRune(g.levels[level])
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%d buckets from %s to %s", len(c.values), c.labels[0], c.labels[len(c.labels)-1])
+	if perColumn > 1 {
+		fmt.Fprintf(&b, ", highest of every %d per character", perColumn)
+	}
+	b.WriteString("\n")
+	b.WriteString(chartStats(c))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// downsample keeps the highest value of each group of consecutive values so
+// that at most n are left, and returns the group size.
+func downsample(values []float64, n int) ([]float64, int) {
+	size := (len(values) + n - 1) / n
+	if size <= 1 {
+		return values, 1
+	}
+	var out []float64
+	for i := 0; i < len(values); i += size {
+		group := values[i:min(i+size, len(values))]
+		highest := group[0]
+		for _, v := range group[1:] {
+			highest = max(highest, v)
+		}
+		out = append(out, highest)
+	}
+	return out, size
+}
+
+// axisMax is the top of the y axis: the highest value, or 1 when every value
+// is zero or negative.
+func axisMax(values []float64) float64 {
+	top := 0.0
+	for _, v := range values {
+		top = max(top, v)
+	}
+	if top == 0 {
+		return 1
+	}
+	return top
+}
+
+// xLabels writes the first and last bucket labels under the plot, the last
+// one right-aligned with the plot, or past it when the plot is narrower
+// than the labels but the width allows.
+func xLabels(labels []string, indent, plotWidth, width int) string {
+	first, last := labels[0], labels[len(labels)-1]
+	line := strings.Repeat(" ", indent) + first
+	if len(labels) > 1 {
+		if gap := max(plotWidth-len(first)-len(last), 2); indent+len(first)+gap+len(last) <= width {
+			line += strings.Repeat(" ", gap) + last
+		} else {
+			line += " ... " + last
+		}
+	}
+	return line + "\n"
+}
+
+// chartStats writes the minimum, maximum and average of the values, with the
+// buckets of the extremes.
+func chartStats(c chart) string {
+	lo, hi := 0, 0
+	sum := 0.0
+	for i, v := range c.values {
+		if v < c.values[lo] {
+			lo = i
+		}
+		if v > c.values[hi] {
+			hi = i
+		}
+		sum += v
+	}
+	return fmt.Sprintf("min %s (%s)  max %s (%s)  avg %s\n",
+		formatChartValue(c.values[lo]), c.labels[lo],
+		formatChartValue(c.values[hi]), c.labels[hi],
+		formatChartValue(sum/float64(len(c.values))))
+}
+
+// formatChartValue prints whole numbers as such and others with four
+// significant digits.
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// delimitedWriter writes rows as CSV (RFC 4180) or TSV. Cells come from
+// TableRenderer by default, or from JSON field paths when Columns is set.
+type delimitedWriter struct {
+	w       *csv.Writer
+	columns []string
+	header  bool
+}
+
+func (p *Printer) newDelimitedWriter() *delimitedWriter {
+	w := csv.NewWriter(p.Out)
+	if p.Format == "tsv" {
+		w.Comma = '\t'
+	}
+	return &delimitedWriter{w: w, columns: p.Columns}
+}
+
+// write prints item as a row, preceded by the header on the first call.
+func (d *delimitedWriter) write(item any) error {
+	var headers, row []string
+	if len(d.columns) > 0 {
+		headers = d.columns
+		var err error
+		if row, err = columnValues(item, d.columns); err != nil {
+			return err
+		}
+	} else {
+		r, ok := item.(TableRenderer)
+		if !ok {
+			return fmt.Errorf("%T has no default columns, use --columns", item)
+		}
+		headers, row = r.TableHeaders(), r.TableRow()
+	}
+
+	if !d.header {
+		if err := d.w.Write(headers); err != nil {
+			return err
+		}
+		d.header = true
+	}
+	if err := d.w.Write(row); err != nil {
+		return err
+	}
+	d.w.Flush()
+	return d.w.Error()
+}
+
+// close writes the header of an empty list when columns were requested, so
+// that the output still has a known shape.
+func (d *delimitedWriter) close() error {
+	if !d.header && len(d.columns) > 0 {
+		if err := d.w.Write(d.columns); err != nil {
+			return err
+		}
+	}
+	d.w.Flush()
+	return d.w.Error()
+}
+
+func (p *Printer) printDelimited(data any) error {
+	d := p.newDelimitedWriter()
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice {
+		for i := range v.Len() {
+			if err := d.write(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	} else if err := d.write(data); err != nil {
+		return err
+	}
+	return d.close()
+}
+
+// columnValues resolves each dotted column path (for example
+// "release_stage.name" or "tags.0") against the JSON form of item.
+func columnValues(item any, columns []string) ([]string, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = cellString(lookupPath(doc, col))
+	}
+	return row, nil
+}
+
+func lookupPath(doc any, path string) any {
+	cur := doc
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			cur = v[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil
+			}
+			cur = v[idx]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+func cellString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,562 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled kubectl-style JSONPath template: literal text mixed
+// with {expressions}, for example `{.data[*].id}` or
+// `{range .data[*]}{.id}{"\t"}{.error_class}{"\n"}{end}`.
+//
+// Supported expressions are fields (.name or ['name']), wildcards ([*] or
+// .*), indexes ([0], [-1]), slices ([1:3]), recursive descent (..name),
+// filters ([?(@.status=="open")], [?(@.events>10)], [?(@.url)]), quoted
+// string literals, and range/end blocks. Paths start at the current range
+// element, or at the document root with $.
+type jsonPath struct {
+	nodes []jpNode
+}
+
+type jpKind int
+
+const (
+	jpText jpKind = iota
+	jpPath
+	jpRange
+)
+
+type jpNode struct {
+	kind     jpKind
+	text     string
+	fromRoot bool
+	steps    []jpStep
+	body     []jpNode
+}
+
+type jpStepKind int
+
+const (
+	jpField jpStepKind = iota
+	jpWildcard
+	jpIndex
+	jpSlice
+	jpRecursive
+	jpFilter
+)
+
+type jpStep struct {
+	kind       jpStepKind
+	name       string
+	index      int
+	start, end *int
+	filter     *jpPredicate
+}
+
+// jpPredicate is a filter such as @.events>10. An empty op tests that the
+// path exists and is not null.
+type jpPredicate struct {
+	fromRoot bool
+	steps    []jpStep
+	op       string
+	value    any
+}
+
+func parseJSONPath(tmpl string) (*jsonPath, error) {
+	tokens, err := splitJSONPath(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	nodes, _, err := buildJSONPath(tokens, false)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPath{nodes: nodes}, nil
+}
+
+type jpToken struct {
+	expr bool
+	text string
+}
+
+// splitJSONPath separates literal text from {expressions}, ignoring braces
+// inside quoted strings.
+func splitJSONPath(tmpl string) ([]jpToken, error) {
+	var tokens []jpToken
+	for len(tmpl) > 0 {
+		open := strings.IndexByte(tmpl, '{')
+		if open < 0 {
+			tokens = append(tokens, jpToken{text: tmpl})
+			break
+		}
+		if open > 0 {
+			tokens = append(tokens, jpToken{text: tmpl[:open]})
+		}
+		end := closingIndex(tmpl[open+1:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("jsonpath: unclosed { in %q", tmpl[open:])
+		}
+		tokens = append(tokens, jpToken{expr: true, text: strings.TrimSpace(tmpl[open+1 : open+1+end])})
+		tmpl = tmpl[open+end+2:]
+	}
+	return tokens, nil
+}
+
+// closingIndex returns the index of the first delim in s that is outside
+// quotes and nested brackets or parentheses, or -1.
+func closingIndex(s string, delim byte) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == delim && depth == 0:
+			return i
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		}
+	}
+	return -1
+}
+
+// buildJSONPath turns tokens into nodes, descending into range blocks. When
+// inRange is set it stops at the matching {end} and returns the tokens after
+// it.
+func buildJSONPath(tokens []jpToken, inRange bool) ([]jpNode, []jpToken, error) {
+	var nodes []jpNode
+	for len(tokens) > 0 {
+		tok := tokens[0]
+		tokens = tokens[1:]
+
+		if !tok.expr {
+			nodes = append(nodes, jpNode{kind: jpText, text: tok.text})
+			continue
+		}
+
+		switch {
+		case tok.text == "end":
+			if !inRange {
+				return nil, nil, fmt.Errorf("jsonpath: {end} without {range}")
+			}
+			return nodes, tokens, nil
+		case strings.HasPrefix(tok.text, "range "):
+			node, err := parsePathNode(strings.TrimSpace(strings.TrimPrefix(tok.text, "range ")))
+			if err != nil {
+				return nil, nil, err
+			}
+			body, rest, err := buildJSONPath(tokens, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			node.kind = jpRange
+			node.body = body
+			nodes = append(nodes, node)
+			tokens = rest
+		case strings.HasPrefix(tok.text, `"`):
+			text, err := strconv.Unquote(tok.text)
+			if err != nil {
+				return nil, nil, fmt.Errorf("jsonpath: invalid string %s", tok.text)
+			}
+			nodes = append(nodes, jpNode{kind: jpText, text: text})
+		case strings.HasPrefix(tok.text, "'"):
+			nodes = append(nodes, jpNode{kind: jpText, text: strings.Trim(tok.text, "'")})
+		default:
+			node, err := parsePathNode(tok.text)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	if inRange {
+		return nil, nil, fmt.Errorf("jsonpath: {range} without {end}")
+	}
+	return nodes, nil, nil
+}
+
+func parsePathNode(expr string) (jpNode, error) {
+	fromRoot, steps, err := parseSteps(expr)
+	if err != nil {
+		return jpNode{}, err
+	}
+	return jpNode{kind: jpPath, fromRoot: fromRoot, steps: steps}, nil
+}
+
+func parseSteps(expr string) (bool, []jpStep, error) {
+	fromRoot := strings.HasPrefix(expr, "$")
+	s := strings.TrimLeft(expr, "$@")
+
+	var steps []jpStep
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := readName(s[2:])
+			if name == "" {
+				return false, nil, fmt.Errorf("jsonpath: expected a field after .. in %q", expr)
+			}
+			steps = append(steps, jpStep{kind: jpRecursive, name: name})
+			s = rest
+		case strings.HasPrefix(s, ".*"):
+			steps = append(steps, jpStep{kind: jpWildcard})
+			s = s[2:]
+		case s[0] == '.':
+			name, rest := readName(s[1:])
+			if name != "" {
+				steps = append(steps, jpStep{kind: jpField, name: name})
+			}
+			s = rest
+		case s[0] == '[':
+			end := closingIndex(s[1:], ']')
+			if end < 0 {
+				return false, nil, fmt.Errorf("jsonpath: unclosed [ in %q", expr)
+			}
+			step, err := parseBracket(strings.TrimSpace(s[1 : end+1]))
+			if err != nil {
+				return false, nil, err
+			}
+			steps = append(steps, step)
+			s = s[end+2:]
+		default:
+			return false, nil, fmt.Errorf("jsonpath: unexpected %q in %q", s, expr)
+		}
+	}
+	return fromRoot, steps, nil
+}
+
+func readName(s string) (string, string) {
+	i := strings.IndexAny(s, ".[")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+func parseBracket(inner string) (jpStep, error) {
+	switch {
+	case inner == "*":
+		return jpStep{kind: jpWildcard}, nil
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		pred, err := parsePredicate(strings.TrimSpace(inner[2 : len(inner)-1]))
+		if err != nil {
+			return jpStep{}, err
+		}
+		return jpStep{kind: jpFilter, filter: pred}, nil
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return jpStep{kind: jpField, name: inner[1 : len(inner)-1]}, nil
+	case strings.Contains(inner, ":"):
+		lo, hi, _ := strings.Cut(inner, ":")
+		step := jpStep{kind: jpSlice}
+		for _, b := range []struct {
+			s   string
+			dst **int
+		}{{lo, &step.start}, {hi, &step.end}} {
+			if b.s = strings.TrimSpace(b.s); b.s == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b.s)
+			if err != nil {
+				return jpStep{}, fmt.Errorf("jsonpath: invalid slice [%s]", inner)
+			}
+			*b.dst = &n
+		}
+		return step, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return jpStep{}, fmt.Errorf("jsonpath: unsupported subscript [%s]", inner)
+		}
+		return jpStep{kind: jpIndex, index: n}, nil
+	}
+}
+
+func parsePredicate(expr string) (*jpPredicate, error) {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		i := indexOutsideQuotes(expr, op)
+		if i < 0 {
+			continue
+		}
+		fromRoot, steps, err := parseSteps(strings.TrimSpace(expr[:i]))
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseLiteral(strings.TrimSpace(expr[i+len(op):]))
+		if err != nil {
+			return nil, err
+		}
+		return &jpPredicate{fromRoot: fromRoot, steps: steps, op: op, value: value}, nil
+	}
+	fromRoot, steps, err := parseSteps(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &jpPredicate{fromRoot: fromRoot, steps: steps}, nil
+}
+
+func indexOutsideQuotes(s, sub string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(s[i:], sub):
+			return i
+		}
+	}
+	return -1
+}
+
+func parseLiteral(s string) (any, error) {
+	switch {
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s == "null":
+		return nil, nil
+	case strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") && len(s) >= 2:
+		return s[1 : len(s)-1], nil
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath: invalid filter value %q", s)
+	}
+	return f, nil
+}
+
+// execute renders the template against the JSON form of data.
+func (jp *jsonPath) execute(w io.Writer, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	renderJSONPath(&buf, jp.nodes, root, root)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func renderJSONPath(buf *bytes.Buffer, nodes []jpNode, root, cur any) {
+	for _, n := range nodes {
+		switch n.kind {
+		case jpText:
+			buf.WriteString(n.text)
+		case jpPath:
+			for i, v := range evalSteps(n.start(root, cur), n.steps, root) {
+				if i > 0 {
+					buf.WriteByte(' ')
+				}
+				buf.WriteString(cellString(v))
+			}
+		case jpRange:
+			for _, v := range evalSteps(n.start(root, cur), n.steps, root) {
+				renderJSONPath(buf, n.body, root, v)
+			}
+		}
+	}
+}
+
+func (n jpNode) start(root, cur any) any {
+	if n.fromRoot {
+		return root
+	}
+	return cur
+}
+
+func evalSteps(node any, steps []jpStep, root any) []any {
+	nodes := []any{node}
+	for _, st := range steps {
+		var next []any
+		for _, n := range nodes {
+			next = st.apply(n, next, root)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (st jpStep) apply(node any, out []any, root any) []any {
+	switch st.kind {
+	case jpField:
+		if m, ok := node.(map[string]any); ok {
+			if v, ok := m[st.name]; ok {
+				out = append(out, v)
+			}
+		}
+	case jpWildcard:
+		out = append(out, children(node)...)
+	case jpIndex:
+		if a, ok := node.([]any); ok {
+			i := st.index
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				out = append(out, a[i])
+			}
+		}
+	case jpSlice:
+		if a, ok := node.([]any); ok {
+			lo, hi := 0, len(a)
+			if st.start != nil {
+				lo = clampIndex(*st.start, len(a))
+			}
+			if st.end != nil {
+				hi = clampIndex(*st.end, len(a))
+			}
+			if lo < hi {
+				out = append(out, a[lo:hi]...)
+			}
+		}
+	case jpRecursive:
+		out = collectRecursive(node, st.name, out)
+	case jpFilter:
+		for _, child := range children(node) {
+			if st.filter.match(child, root) {
+				out = append(out, child)
+			}
+		}
+	}
+	return out
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+// children returns array elements, or object values in key order.
+func children(node any) []any {
+	switch v := node.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]any, len(keys))
+		for i, k := range keys {
+			out[i] = v[k]
+		}
+		return out
+	}
+	return nil
+}
+
+func collectRecursive(node any, name string, out []any) []any {
+	if m, ok := node.(map[string]any); ok {
+		if v, ok := m[name]; ok {
+			out = append(out, v)
+		}
+	}
+	for _, child := range children(node) {
+		out = collectRecursive(child, name, out)
+	}
+	return out
+}
+
+func (p *jpPredicate) match(node, root any) bool {
+	start := node
+	if p.fromRoot {
+		start = root
+	}
+	values := evalSteps(start, p.steps, root)
+	if p.op == "" {
+		return len(values) > 0 && values[0] != nil
+	}
+	if len(values) == 0 {
+		return false
+	}
+	cmp, ok := compareJSON(values[0], p.value)
+	if !ok {
+		return p.op == "!="
+	}
+	switch p.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compareJSON orders a decoded JSON value against a filter literal. ok is
+// false when the two are not comparable.
+func compareJSON(v, lit any) (int, bool) {
+	switch l := lit.(type) {
+	case float64:
+		n, isNum := v.(json.Number)
+		if !isNum {
+			return 0, false
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case f < l:
+			return -1, true
+		case f > l:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		s, isStr := v.(string)
+		if !isStr {
+			return 0, false
+		}
+		return strings.Compare(s, l), true
+	case bool:
+		b, isBool := v.(bool)
+		if !isBool || b != l {
+			return 1, isBool
+		}
+		return 0, true
+	case nil:
+		if v == nil {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
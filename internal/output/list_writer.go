@@ -0,0 +1,134 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// ListWriter prints a list one item at a time so that long paginated lists
+// start appearing before the last page is fetched. JSON output is the same
+// ListResult envelope PrintList produces, ndjson items are written one per
+// line, csv and tsv rows are flushed as they come, and table rows are
+// aligned once the list is closed. Templates and charts see the whole list,
+// so the template, jsonpath and chart formats buffer items until Close.
+type ListWriter struct {
+	p     *Printer
+	count int
+	tw    *tabwriter.Writer
+	d     *delimitedWriter
+	items []any
+}
+
+// NewListWriter returns a ListWriter printing in p's format. Nothing is
+// written until the first item or Close.
+func (p *Printer) NewListWriter() *ListWriter {
+	lw := &ListWriter{p: p}
+	if p.Format == "csv" || p.Format == "tsv" {
+		lw.d = p.newDelimitedWriter()
+	}
+	return lw
+}
+
+// Count returns the number of items written so far.
+func (lw *ListWriter) Count() int {
+	return lw.count
+}
+
+// Write prints a single item.
+func (lw *ListWriter) Write(item any) error {
+	switch lw.p.Format {
+	case "table", "pretty":
+		return lw.writeRow(item)
+	case "ndjson":
+		if err := lw.p.printNDJSON(item); err != nil {
+			return err
+		}
+		lw.count++
+		return nil
+	case "csv", "tsv":
+		if err := lw.d.write(item); err != nil {
+			return err
+		}
+		lw.count++
+		return nil
+	case "template", "jsonpath":
+		if lw.count == 0 {
+			if _, err := lw.p.compileTemplate(); err != nil {
+				return err
+			}
+		}
+		lw.items = append(lw.items, item)
+		lw.count++
+		return nil
+	case "chart":
+		lw.items = append(lw.items, item)
+		lw.count++
+		return nil
+	}
+
+	data, err := json.MarshalIndent(item, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ","
+	if lw.count == 0 {
+		sep = "{\n  \"data\": ["
+	}
+	if _, err := fmt.Fprintf(lw.p.Out, "%s\n    %s", sep, data); err != nil {
+		return err
+	}
+	lw.count++
+	return nil
+}
+
+func (lw *ListWriter) writeRow(item any) error {
+	r, ok := item.(TableRenderer)
+	if !ok {
+		return fmt.Errorf("%T cannot be rendered as a table", item)
+	}
+	if lw.tw == nil {
+		lw.tw = tabwriter.NewWriter(lw.p.Out, 0, 0, 2, ' ', 0)
+		writeTableRow(lw.tw, r.TableHeaders())
+	}
+	writeTableRow(lw.tw, r.TableRow())
+	lw.count++
+	return nil
+}
+
+// Close terminates the list. hasMore is reported in the JSON envelope and
+// should be set when the list was cut short.
+func (lw *ListWriter) Close(hasMore bool) error {
+	switch lw.p.Format {
+	case "table", "pretty":
+		if lw.tw == nil {
+			_, err := fmt.Fprintln(lw.p.Out, "No results found.")
+			return err
+		}
+		return lw.tw.Flush()
+	case "ndjson":
+		return lw.p.printNDJSONSummary(lw.count, hasMore)
+	case "csv", "tsv":
+		return lw.d.close()
+	case "template", "jsonpath", "chart":
+		return lw.p.PrintList(lw.items, lw.count, hasMore)
+	}
+
+	closing := "\n  ]"
+	if lw.count == 0 {
+		closing = "{\n  \"data\": []"
+	}
+	_, err := fmt.Fprintf(lw.p.Out, "%s,\n  \"total_count\": %d,\n  \"has_more\": %t\n}\n", closing, lw.count, hasMore)
+	return err
+}
+
+func writeTableRow(w io.Writer, cells []string) {
+	for i, cell := range cells {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, cell)
+	}
+	fmt.Fprintln(w)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 )
 
@@ -14,6 +15,9 @@ const (
 	ExitConfig  = 2
 	ExitAPI     = 3
 	ExitNetwork = 4
+	// ExitCheckFailed means the command ran but a check it evaluates, such
+	// as a release gate threshold, did not pass.
+	ExitCheckFailed = 5
 )
 
 type ListResult struct {
@@ -26,14 +30,44 @@ type Printer struct {
 	Format string
 	Out    io.Writer
 	ErrOut io.Writer
+	// Columns selects the JSON fields, as dotted paths, printed by the csv
+	// and tsv formats instead of the table columns.
+	Columns []string
+	// Template is the Go template or JSONPath expression used by the
+	// template and jsonpath formats.
+	Template string
+	// Color enables ANSI styling in the pretty format.
+	Color bool
+	// ChartStyle is the chart format's style, ChartBars by default or
+	// ChartSparkline.
+	ChartStyle string
+	// Width is the width the chart format fits in, 80 columns when 0.
+	Width int
+	// Unicode draws charts with block characters instead of plain ASCII.
+	Unicode bool
 }
 
+// NewPrinter returns a printer writing to stdout and stderr. The template
+// and jsonpath formats also accept their expression inline, as in
+// "jsonpath={.data[*].id}", and the chart format its style, as in
+// "chart=sparkline".
 func NewPrinter(format string) *Printer {
-	return &Printer{
-		Format: format,
-		Out:    os.Stdout,
-		ErrOut: os.Stderr,
+	p := &Printer{
+		Format:  format,
+		Out:     os.Stdout,
+		ErrOut:  os.Stderr,
+		Color:   colorEnabled(os.Stdout),
+		Width:   terminalWidth(os.Stdout),
+		Unicode: isTerminal(os.Stdout),
 	}
+	if kind, tmpl, ok := strings.Cut(format, "="); ok && (kind == "template" || kind == "jsonpath") {
+		p.Format = kind
+		p.Template = tmpl
+	} else if ok && kind == "chart" {
+		p.Format = kind
+		p.ChartStyle = tmpl
+	}
+	return p
 }
 
 func (p *Printer) PrintJSON(v any) error {
@@ -48,22 +82,92 @@ func (p *Printer) PrintList(data any, totalCount int, hasMore bool) error {
 		TotalCount: totalCount,
 		HasMore:    hasMore,
 	}
-	if p.Format == "table" {
+	switch p.Format {
+	case "table", "pretty":
 		return p.printTable(data)
+	case "ndjson":
+		return p.printNDJSONList(data, totalCount, hasMore)
+	case "csv", "tsv":
+		return p.printDelimited(data)
+	case "template":
+		return p.executeTemplate(data)
+	case "jsonpath":
+		return p.executeTemplate(result)
+	case "chart":
+		return p.printChart(data)
 	}
 	return p.PrintJSON(result)
 }
 
 func (p *Printer) PrintSingle(data any) error {
-	if p.Format == "table" {
+	switch p.Format {
+	case "table":
 		return p.printTable(data)
+	case "pretty":
+		return p.printPretty(data)
+	case "ndjson":
+		return p.printNDJSON(data)
+	case "csv", "tsv":
+		return p.printDelimited(data)
+	case "template", "jsonpath":
+		return p.executeTemplate(data)
+	case "chart":
+		return fmt.Errorf("the chart format is not supported for %T", data)
 	}
 	return p.PrintJSON(data)
 }
 
+// Tabular reports whether the format prints rows, in which case lists
+// should be passed as TableRenderers.
+func (p *Printer) Tabular() bool {
+	switch p.Format {
+	case "table", "pretty", "csv", "tsv":
+		return true
+	}
+	return false
+}
+
+// printNDJSON writes v as a single line of compact JSON.
+func (p *Printer) printNDJSON(v any) error {
+	return json.NewEncoder(p.Out).Encode(v)
+}
+
+// printNDJSONList writes one line per element of data, which must marshal
+// to a JSON array, followed by the has_more summary on ErrOut.
+func (p *Printer) printNDJSONList(data any, totalCount int, hasMore bool) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return p.printNDJSON(data)
+	}
+	for _, item := range items {
+		if _, err := fmt.Fprintf(p.Out, "%s\n", item); err != nil {
+			return err
+		}
+	}
+	return p.printNDJSONSummary(totalCount, hasMore)
+}
+
+// printNDJSONSummary tells ndjson consumers that a list is incomplete.
+// It goes to ErrOut so that stdout only ever carries items, and is skipped
+// when the list is complete.
+func (p *Printer) printNDJSONSummary(totalCount int, hasMore bool) error {
+	if !hasMore {
+		return nil
+	}
+	summary := struct {
+		TotalCount int  `json:"total_count"`
+		HasMore    bool `json:"has_more"`
+	}{totalCount, true}
+	return json.NewEncoder(p.ErrOut).Encode(summary)
+}
+
 // FormatError writes the error message to ErrOut without exiting.
 func (p *Printer) FormatError(msg string) {
-	if p.Format == "json" {
+	if p.Format == "json" || p.Format == "ndjson" {
 		errObj := map[string]string{"error": msg}
 		data, _ := json.Marshal(errObj)
 		fmt.Fprintln(p.ErrOut, string(data))
@@ -91,41 +195,13 @@ func (p *Printer) printTable(data any) error {
 			fmt.Fprintln(p.Out, "No results found.")
 			return nil
 		}
-		headers := v[0].TableHeaders()
-		for i, h := range headers {
-			if i > 0 {
-				fmt.Fprint(w, "\t")
-			}
-			fmt.Fprint(w, h)
-		}
-		fmt.Fprintln(w)
+		writeTableRow(w, v[0].TableHeaders())
 		for _, item := range v {
-			row := item.TableRow()
-			for i, cell := range row {
-				if i > 0 {
-					fmt.Fprint(w, "\t")
-				}
-				fmt.Fprint(w, cell)
-			}
-			fmt.Fprintln(w)
+			writeTableRow(w, item.TableRow())
 		}
 	case TableRenderer:
-		headers := v.TableHeaders()
-		for i, h := range headers {
-			if i > 0 {
-				fmt.Fprint(w, "\t")
-			}
-			fmt.Fprint(w, h)
-		}
-		fmt.Fprintln(w)
-		row := v.TableRow()
-		for i, cell := range row {
-			if i > 0 {
-				fmt.Fprint(w, "\t")
-			}
-			fmt.Fprint(w, cell)
-		}
-		fmt.Fprintln(w)
+		writeTableRow(w, v.TableHeaders())
+		writeTableRow(w, v.TableRow())
 	default:
 		return p.PrintJSON(data)
 	}
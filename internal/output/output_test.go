@@ -3,8 +3,12 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
 )
 
 // ---------------------------------------------------------------------------
@@ -484,3 +488,729 @@ func TestFormatError_EmptyMessage(t *testing.T) {
 		t.Errorf("expected empty error, got: %q", parsed["error"])
 	}
 }
+
+// ---------------------------------------------------------------------------
+// ListWriter
+// ---------------------------------------------------------------------------
+
+func TestListWriter_JSONMatchesPrintList(t *testing.T) {
+	items := []map[string]any{{"id": "1", "tags": []string{"a"}}, {"id": "2", "note": "<b>"}}
+
+	var want bytes.Buffer
+	if err := (&Printer{Format: "json", Out: &want}).PrintList(items, 2, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got bytes.Buffer
+	lw := (&Printer{Format: "json", Out: &got}).NewListWriter()
+	for _, item := range items {
+		if err := lw.Write(item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := lw.Close(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.String() != want.String() {
+		t.Errorf("streamed output differs from PrintList:\ngot:\n%s\nwant:\n%s", got.String(), want.String())
+	}
+}
+
+func TestListWriter_JSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	lw := (&Printer{Format: "json", Out: &buf}).NewListWriter()
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if data, ok := result["data"].([]any); !ok || len(data) != 0 {
+		t.Errorf("expected an empty data array, got %v", result["data"])
+	}
+	if result["total_count"] != float64(0) {
+		t.Errorf("expected total_count=0, got %v", result["total_count"])
+	}
+}
+
+func TestListWriter_TableMatchesPrintList(t *testing.T) {
+	items := []TableRenderer{
+		mockRenderer{id: "1", name: "first"},
+		mockRenderer{id: "22", name: "second"},
+	}
+
+	var want bytes.Buffer
+	if err := (&Printer{Format: "table", Out: &want}).PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got bytes.Buffer
+	lw := (&Printer{Format: "table", Out: &got}).NewListWriter()
+	for _, item := range items {
+		if err := lw.Write(item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.String() != want.String() {
+		t.Errorf("streamed table differs from PrintList:\ngot:\n%s\nwant:\n%s", got.String(), want.String())
+	}
+	if lw.Count() != 2 {
+		t.Errorf("expected Count()=2, got %d", lw.Count())
+	}
+}
+
+func TestListWriter_TableEmptyAndNonRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	lw := (&Printer{Format: "table", Out: &buf}).NewListWriter()
+	if err := lw.Write(map[string]string{"id": "1"}); err == nil {
+		t.Error("expected an error for an item without table rendering")
+	}
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No results found") {
+		t.Errorf("expected 'No results found', got: %s", buf.String())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NDJSON
+// ---------------------------------------------------------------------------
+
+func TestPrintList_NDJSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	p := &Printer{Format: "ndjson", Out: &out, ErrOut: &errOut}
+
+	items := []map[string]any{{"id": "1", "nested": map[string]int{"n": 1}}, {"id": "2"}}
+	if err := p.PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "{\"id\":\"1\",\"nested\":{\"n\":1}}\n{\"id\":\"2\"}\n"
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("expected no summary for a complete list, got %q", errOut.String())
+	}
+}
+
+func TestPrintList_NDJSONHasMoreSummary(t *testing.T) {
+	var out, errOut bytes.Buffer
+	p := &Printer{Format: "ndjson", Out: &out, ErrOut: &errOut}
+
+	if err := p.PrintList([]string{"a"}, 1, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "\"a\"\n" {
+		t.Errorf("expected a single line on stdout, got %q", out.String())
+	}
+	if errOut.String() != "{\"total_count\":1,\"has_more\":true}\n" {
+		t.Errorf("unexpected summary: %q", errOut.String())
+	}
+}
+
+func TestPrintSingle_NDJSON(t *testing.T) {
+	var out bytes.Buffer
+	p := &Printer{Format: "ndjson", Out: &out, ErrOut: &bytes.Buffer{}}
+
+	if err := p.PrintSingle(map[string]any{"id": "1", "tags": []string{"a", "b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "{\"id\":\"1\",\"tags\":[\"a\",\"b\"]}\n" {
+		t.Errorf("expected compact single line, got %q", out.String())
+	}
+}
+
+func TestListWriter_NDJSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	lw := (&Printer{Format: "ndjson", Out: &out, ErrOut: &errOut}).NewListWriter()
+
+	for _, id := range []string{"1", "2"} {
+		if err := lw.Write(map[string]string{"id": id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := lw.Close(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.String() != "{\"id\":\"1\"}\n{\"id\":\"2\"}\n" {
+		t.Errorf("unexpected stdout: %q", out.String())
+	}
+	if errOut.String() != "{\"total_count\":2,\"has_more\":true}\n" {
+		t.Errorf("unexpected summary: %q", errOut.String())
+	}
+}
+
+func TestFormatError_NDJSON(t *testing.T) {
+	var errOut bytes.Buffer
+	p := &Printer{Format: "ndjson", Out: &bytes.Buffer{}, ErrOut: &errOut}
+
+	p.FormatError("boom")
+	if strings.TrimSpace(errOut.String()) != `{"error":"boom"}` {
+		t.Errorf("expected JSON error, got %q", errOut.String())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CSV and TSV
+// ---------------------------------------------------------------------------
+
+func TestPrintList_CSVDefaultColumns(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "csv", Out: &buf}
+
+	items := []TableRenderer{
+		mockRenderer{id: "1", name: "plain"},
+		mockRenderer{id: "2", name: "has, comma and \"quotes\"\nand a newline"},
+	}
+	if err := p.PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "ID,NAME\n1,plain\n2,\"has, comma and \"\"quotes\"\"\nand a newline\"\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestPrintList_TSVColumns(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "tsv", Out: &buf, Columns: []string{"id", "release_stage.name", "count", "ok", "tags", "tags.1"}}
+
+	items := []map[string]any{
+		{"id": "a", "release_stage": map[string]any{"name": "production"}, "count": 12345678901, "ok": true, "tags": []string{"x", "y"}},
+		{"id": "b"},
+	}
+	if err := p.PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id\trelease_stage.name\tcount\tok\ttags\ttags.1\n" +
+		"a\tproduction\t12345678901\ttrue\t\"[\"\"x\"\",\"\"y\"\"]\"\ty\n" +
+		"b\t\t\t\t\t\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestPrintSingle_CSVRequiresColumnsForPlainValues(t *testing.T) {
+	p := &Printer{Format: "csv", Out: &bytes.Buffer{}}
+	if err := p.PrintSingle(map[string]string{"id": "1"}); err == nil {
+		t.Error("expected an error without --columns for a non-table value")
+	}
+
+	var buf bytes.Buffer
+	p = &Printer{Format: "csv", Out: &buf, Columns: []string{"id"}}
+	if err := p.PrintSingle(map[string]string{"id": "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "id\n1\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestListWriter_CSVMatchesPrintList(t *testing.T) {
+	items := []TableRenderer{mockRenderer{id: "1", name: "first"}, mockRenderer{id: "2", name: "second"}}
+
+	var want bytes.Buffer
+	if err := (&Printer{Format: "csv", Out: &want}).PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got bytes.Buffer
+	lw := (&Printer{Format: "csv", Out: &got}).NewListWriter()
+	for _, item := range items {
+		if err := lw.Write(item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("expected %q, got %q", want.String(), got.String())
+	}
+}
+
+func TestListWriter_CSVEmptyWithColumnsPrintsHeader(t *testing.T) {
+	var buf bytes.Buffer
+	lw := (&Printer{Format: "csv", Out: &buf, Columns: []string{"id", "events"}}).NewListWriter()
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "id,events\n" {
+		t.Errorf("expected header only, got %q", buf.String())
+	}
+}
+
+func TestPrinterTabular(t *testing.T) {
+	for format, want := range map[string]bool{"table": true, "csv": true, "tsv": true, "json": false, "ndjson": false} {
+		if got := (&Printer{Format: format}).Tabular(); got != want {
+			t.Errorf("Tabular() for %s = %v, want %v", format, got, want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Template and JSONPath
+// ---------------------------------------------------------------------------
+
+func TestNewPrinter_InlineTemplate(t *testing.T) {
+	p := NewPrinter("jsonpath={.data[*].id}")
+	if p.Format != "jsonpath" || p.Template != "{.data[*].id}" {
+		t.Errorf("expected jsonpath format with inline expression, got %q / %q", p.Format, p.Template)
+	}
+	p = NewPrinter("json")
+	if p.Format != "json" || p.Template != "" {
+		t.Errorf("expected plain json format, got %q / %q", p.Format, p.Template)
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]any{
+		"data": []map[string]any{
+			{"id": "a", "events": 3, "status": "open", "app": map[string]any{"version": "1.0"}, "url": "https://x/a"},
+			{"id": "b", "events": 12, "status": "fixed", "app": map[string]any{"version": "1.1"}},
+			{"id": "c", "events": 12345678901, "status": "open", "tags": []string{"x", "y"}},
+		},
+		"total_count": 3,
+		"has_more":    false,
+	}
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{.data[*].id}`, "a b c"},
+		{`{.data[0].id}`, "a"},
+		{`{.data[-1].events}`, "12345678901"},
+		{`{.data[0:2].id}`, "a b"},
+		{`{.data[1:].id}`, "b c"},
+		{`{.data[*].app.version}`, "1.0 1.1"},
+		{`{..version}`, "1.0 1.1"},
+		{`{.data[?(@.status=="open")].id}`, "a c"},
+		{`{.data[?(@.status!='open')].id}`, "b"},
+		{`{.data[?(@.events>=12)].id}`, "b c"},
+		{`{.data[?(@.url)].id}`, "a"},
+		{`{.data[2].tags}`, `["x","y"]`},
+		{`{.data[2]['tags'][1]}`, "y"},
+		{`{.has_more} {.total_count}`, "false 3"},
+		{`{.missing}`, ""},
+		{`{range .data[*]}{.id}{"\t"}{.status}{"\n"}{end}`, "a\topen\nb\tfixed\nc\topen\n"},
+		{`{range .data[*]}{.id}/{$.total_count} {end}`, "a/3 b/3 c/3 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			var buf bytes.Buffer
+			p := &Printer{Format: "jsonpath", Out: &buf, Template: tt.tmpl}
+			if err := p.PrintSingle(doc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestJSONPath_ParseErrors(t *testing.T) {
+	for _, tmpl := range []string{`{.data`, `{range .data[*]}{.id}`, `{end}`, `{.data[x]}`, `{.data[1:y]}`, `{..}`} {
+		if _, err := parseJSONPath(tmpl); err == nil {
+			t.Errorf("expected a parse error for %q", tmpl)
+		}
+	}
+}
+
+func TestPrintList_JSONPathSeesEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "jsonpath", Out: &buf, Template: "{.data[*].id} {.has_more}"}
+	if err := p.PrintList([]map[string]string{{"id": "1"}, {"id": "2"}}, 2, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "1 2 true" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestPrintList_TemplateSeesItems(t *testing.T) {
+	type item struct{ ID, Message string }
+	var buf bytes.Buffer
+	p := &Printer{Format: "template", Out: &buf, Template: `{{range .}}{{.ID}}:{{truncate 8 .Message}};{{end}}`}
+	items := []item{{"1", "short"}, {"2", "a much longer message"}}
+	if err := p.PrintList(items, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "1:short;2:a muc...;" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestPrint_TemplateErrors(t *testing.T) {
+	p := &Printer{Format: "template", Out: &bytes.Buffer{}}
+	if err := p.PrintSingle(map[string]string{}); err == nil || !strings.Contains(err.Error(), "--template is required") {
+		t.Errorf("expected missing template error, got %v", err)
+	}
+	p.Template = "{{.ID"
+	if err := p.PrintSingle(map[string]string{}); err == nil {
+		t.Error("expected a template parse error")
+	}
+}
+
+func TestListWriter_TemplateFailsOnFirstItem(t *testing.T) {
+	var buf bytes.Buffer
+	lw := (&Printer{Format: "template", Out: &buf, Template: "{{range .}}"}).NewListWriter()
+	if err := lw.Write(map[string]string{"id": "1"}); err == nil {
+		t.Fatal("expected the invalid template to fail before buffering")
+	}
+
+	lw = (&Printer{Format: "template", Out: &buf, Template: `{{len .}}`}).NewListWriter()
+	for range 3 {
+		if err := lw.Write(map[string]string{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := lw.Close(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "3" {
+		t.Errorf("expected the template to see all 3 items, got %q", buf.String())
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	oldNow := now
+	now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = oldNow }()
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"date", formatDate("2006-01-02", "2024-05-30T08:00:00Z"), "2024-05-30"},
+		{"date passthrough", formatDate("2006-01-02", "not a date"), "not a date"},
+		{"ago seconds", formatAgo("2024-06-01T11:59:30Z"), "just now"},
+		{"ago minutes", formatAgo("2024-06-01T11:15:00Z"), "45m ago"},
+		{"ago hours", formatAgo("2024-06-01T09:00:00Z"), "3h ago"},
+		{"ago days", formatAgo(time.Date(2024, 5, 29, 12, 0, 0, 0, time.UTC)), "3d ago"},
+		{"truncate short", truncate(10, "short"), "short"},
+		{"truncate", truncate(6, "héllo world"), "hél..."},
+		{"truncate tiny", truncate(2, "hello"), "he"},
+		{"padLeft", padLeft(5, 42), "   42"},
+		{"padRight", padRight(4, "é"), "é   "},
+		{"padRight overflow", padRight(2, "long"), "long"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, tt.got)
+		}
+	}
+
+	if s, err := toJSON(map[string]int{"a": 1}); err != nil || s != `{"a":1}` {
+		t.Errorf("json: expected {\"a\":1}, got %q (%v)", s, err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Pretty
+// ---------------------------------------------------------------------------
+
+func prettyTestEvent() *models.Event {
+	return &models.Event{
+		ID:         "ev-1",
+		ErrorClass: "IllegalStateException",
+		Message:    "Cart is empty",
+		Severity:   "error",
+		Unhandled:  true,
+		Context:    "CheckoutActivity",
+		Exceptions: json.RawMessage(`[
+			{"error_class": "IllegalStateException", "message": "Cart is empty", "stacktrace": [
+				{"file": "Checkout.kt", "line_number": 87, "method": "submitOrder", "in_project": true,
+				 "code": {"86": "if (cart.isEmpty()) {", "87": "    throw IllegalStateException()", "88": "}"}},
+				{"file": "View.java", "line_number": 7448, "method": "performClick", "in_project": false},
+				{"file": "ZygoteInit.java", "line_number": 930, "method": "main", "in_project": false}
+			]},
+			{"error_class": "NoSuchElementException", "message": "List is empty.", "stacktrace": [
+				{"file": "vendor.js", "line_number": 2, "column_number": 118, "method": "first", "in_project": false}
+			]}
+		]`),
+		Threads: json.RawMessage(`[
+			{"id": 2, "name": "main", "state": "RUNNABLE", "error_reporting_thread": true},
+			{"id": "17", "name": "OkHttp Dispatcher", "state": "WAITING"}
+		]`),
+	}
+}
+
+func TestPrintSingle_PrettyEvent(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "pretty", Out: &buf}
+	if err := p.PrintSingle(prettyTestEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"IllegalStateException: Cart is empty\n",
+		"  error · unhandled · CheckoutActivity\n",
+		"  → Checkout.kt:87 in submitOrder\n",
+		"        86 │ if (cart.isEmpty()) {\n",
+		"      > 87 │     throw IllegalStateException()\n",
+		"    ... 2 vendor frames\n",
+		"Caused by: NoSuchElementException: List is empty.\n",
+		"    vendor.js:2:118 in first\n",
+		"Threads\n",
+		"  * 2 main (RUNNABLE)\n",
+		"    17 OkHttp Dispatcher (WAITING)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("expected no ANSI codes with Color disabled")
+	}
+	if strings.Contains(out, "View.java") {
+		t.Error("expected vendor frames to be collapsed when the trace has project frames")
+	}
+}
+
+func TestPrintSingle_PrettyColor(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "pretty", Out: &buf, Color: true}
+	if err := p.PrintSingle(*prettyTestEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[1;31mIllegalStateException\x1b[0m") {
+		t.Errorf("expected a colored error class, got:\n%q", buf.String())
+	}
+}
+
+func TestPrintSingle_PrettyFallsBackToTable(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "pretty", Out: &buf}
+	if err := p.PrintSingle(mockRenderer{id: "1", name: "first"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ID") || !strings.Contains(buf.String(), "first") {
+		t.Errorf("expected table output, got %q", buf.String())
+	}
+}
+
+func TestPrintSingle_PrettyInvalidExceptions(t *testing.T) {
+	p := &Printer{Format: "pretty", Out: &bytes.Buffer{}}
+	err := p.PrintSingle(&models.Event{Exceptions: json.RawMessage(`{"bad": true}`)})
+	if err == nil || !strings.Contains(err.Error(), "decoding exceptions") {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if colorEnabled(w) {
+		t.Error("expected no color for a pipe")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if colorEnabled(os.Stdout) {
+		t.Error("expected NO_COLOR to disable color")
+	}
+}
+
+func TestPrintSingle_PrettyEventWithSource(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "pretty", Out: &buf}
+	err := p.PrintSingle(models.EventWithSource{
+		Event: prettyTestEvent(),
+		SourceFrames: []models.SourceFrame{
+			{
+				File: "/app/Checkout.kt", LineNumber: 87, Method: "submitOrder", Path: "app/Checkout.kt",
+				Code:  []models.CodeLine{{Number: 86, Text: "if (cart.isEmpty()) {"}, {Number: 87, Text: "    error(\"empty\")"}},
+				Blame: &models.Blame{Commit: "4f2c9e1d0a7b", Author: "Ada Lovelace", Date: "2024-05-01", Summary: "Throw on empty cart"},
+			},
+			{Exception: 1, File: "Cart.kt", LineNumber: 42, Method: "first", Error: "not found under ."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"IllegalStateException: Cart is empty\n",
+		"Local source\n",
+		"  → app/Checkout.kt:87 in submitOrder\n",
+		"      > 87 │     error(\"empty\")\n",
+		"      blame: 4f2c9e1d Ada Lovelace, 2024-05-01 · Throw on empty cart\n",
+		"  ✗ Cart.kt:42 (not found under .)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Chart
+// ---------------------------------------------------------------------------
+
+func chartTestBuckets(counts ...int) []models.TrendBucket {
+	var buckets []models.TrendBucket
+	for i, n := range counts {
+		from := time.Date(2024, 5, 1+i, 0, 0, 0, 0, time.UTC)
+		buckets = append(buckets, models.TrendBucket{
+			From:        from.Format("2006-01-02"),
+			To:          from.AddDate(0, 0, 1).Format("2006-01-02"),
+			EventsCount: n,
+		})
+	}
+	return buckets
+}
+
+func TestPrintList_ChartBarsASCII(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "chart", Out: &buf, Width: 30}
+	if err := p.PrintList(chartTestBuckets(0, 2, 8, 4), 4, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `events_count, 4 buckets
+8 +        ###
+  |        ###
+  |        ###
+4 +        ###
+  |        ### ###
+  |        ### ###
+  |    ### ### ###
+0 +    ### ### ###
+  +----------------
+   2024-05-01  2024-05-04
+min 0 (2024-05-01)  max 8 (2024-05-03)  avg 3.5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestPrintList_ChartSparklineUnicode(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter("chart=sparkline")
+	p.Out, p.Width, p.Unicode = &buf, 80, true
+	if err := p.PrintList(chartTestBuckets(0, 1, 4, 8), 4, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "events_count  ▁▄█\n" +
+		"4 buckets from 2024-05-01 to 2024-05-04\n" +
+		"min 0 (2024-05-01)  max 8 (2024-05-04)  avg 3.25\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestPrintList_ChartFitsWidth(t *testing.T) {
+	counts := make([]int, 100)
+	for i := range counts {
+		counts[i] = i % 10
+	}
+	counts[57] = 50
+
+	var buf bytes.Buffer
+	p := &Printer{Format: "chart", Out: &buf, Width: 40, Unicode: true}
+	if err := p.PrintList(chartTestBuckets(counts...), len(counts), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "events_count, 100 buckets (highest of every 3 per bar)") {
+		t.Errorf("expected a downsampling note, got:\n%s", out)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if n := len([]rune(line)); n > 40 && !strings.HasPrefix(line, "min ") && !strings.HasPrefix(line, "events_count") {
+			t.Errorf("line of %d columns exceeds the width: %q", n, line)
+		}
+	}
+	if !strings.Contains(out, "max 50 (2024-06-27)") {
+		t.Errorf("expected the maximum with its bucket, got:\n%s", out)
+	}
+}
+
+func TestPrint_ChartErrors(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Printer{Format: "chart", Out: &buf}
+	if err := p.PrintList([]models.Project{{ID: "p1"}}, 1, false); err == nil || !strings.Contains(err.Error(), "not supported for models.Project") {
+		t.Errorf("expected an unsupported item error, got %v", err)
+	}
+	if err := p.PrintSingle(models.Project{ID: "p1"}); err == nil {
+		t.Error("expected an error for a single item")
+	}
+
+	p.ChartStyle = "pie"
+	if err := p.PrintList(chartTestBuckets(1), 1, false); err == nil || !strings.Contains(err.Error(), `unknown chart style "pie"`) {
+		t.Errorf("expected an unknown style error, got %v", err)
+	}
+
+	p.ChartStyle = ""
+	buf.Reset()
+	if err := p.PrintList([]models.TrendBucket{}, 0, false); err != nil || buf.String() != "No results found.\n" {
+		t.Errorf("expected no results, got %q, %v", buf.String(), err)
+	}
+}
+
+func TestListWriter_ChartMatchesPrintList(t *testing.T) {
+	buckets := chartTestBuckets(3, 1, 2)
+
+	var want bytes.Buffer
+	p := &Printer{Format: "chart", Out: &want, Width: 50}
+	if err := p.PrintList(buckets, len(buckets), false); err != nil {
+		t.Fatal(err)
+	}
+
+	var got bytes.Buffer
+	p.Out = &got
+	lw := p.NewListWriter()
+	for _, b := range buckets {
+		if err := lw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := lw.Close(false); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("expected:\n%s\ngot:\n%s", want.String(), got.String())
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+	if got := terminalWidth(os.Stdout); got != 132 {
+		t.Errorf("expected COLUMNS to set the width, got %d", got)
+	}
+
+	t.Setenv("COLUMNS", "")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if got := terminalWidth(w); got != 0 {
+		t.Errorf("expected no width for a pipe, got %d", got)
+	}
+	if isTerminal(w) {
+		t.Error("expected a pipe not to be a terminal")
+	}
+}
@@ -0,0 +1,222 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+// ANSI styles used by the pretty format when Color is enabled.
+const (
+	styleBold   = "1"
+	styleDim    = "2"
+	styleRed    = "31"
+	styleYellow = "33"
+	styleCyan   = "36"
+)
+
+// colorEnabled reports whether f is a terminal and NO_COLOR is unset.
+func colorEnabled(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return isTerminal(f)
+}
+
+func (p *Printer) paint(s string, styles ...string) string {
+	if !p.Color || s == "" {
+		return s
+	}
+	return "\x1b[" + strings.Join(styles, ";") + "m" + s + "\x1b[0m"
+}
+
+// printPretty renders events as a readable stack trace. Other values fall
+// back to the table layout.
+func (p *Printer) printPretty(data any) error {
+	switch v := data.(type) {
+	case *models.Event:
+		return p.printEvent(*v)
+	case models.Event:
+		return p.printEvent(v)
+	case *models.EventWithSource:
+		return p.printEventWithSource(*v)
+	case models.EventWithSource:
+		return p.printEventWithSource(v)
+	}
+	return p.printTable(data)
+}
+
+func (p *Printer) printEvent(e models.Event) error {
+	exceptions, err := e.ParseExceptions()
+	if err != nil {
+		return fmt.Errorf("decoding exceptions: %w", err)
+	}
+	threads, err := e.ParseThreads()
+	if err != nil {
+		return fmt.Errorf("decoding threads: %w", err)
+	}
+
+	w := &strings.Builder{}
+	fmt.Fprintf(w, "%s: %s\n", p.paint(e.ErrorClass, styleBold, styleRed), p.paint(e.Message, styleBold))
+
+	var details []string
+	for _, d := range []string{e.Severity, handledLabel(e.Unhandled), e.Context, e.ReceivedAt} {
+		if d != "" {
+			details = append(details, d)
+		}
+	}
+	fmt.Fprintf(w, "  %s\n", p.paint(strings.Join(details, " · "), styleDim))
+	if e.URL != "" {
+		fmt.Fprintf(w, "  %s\n", p.paint(e.URL, styleDim))
+	}
+
+	for i, ex := range exceptions {
+		fmt.Fprintln(w)
+		prefix := ""
+		if i > 0 {
+			prefix = "Caused by: "
+		}
+		fmt.Fprintf(w, "%s%s: %s\n", prefix, p.paint(ex.ErrorClass, styleBold, styleRed), ex.Message)
+		p.writeFrames(w, ex.Stacktrace)
+	}
+
+	if len(threads) > 0 {
+		fmt.Fprintf(w, "\n%s\n", p.paint("Threads", styleBold))
+		for _, t := range threads {
+			marker := " "
+			if t.ErrorReportingThread {
+				marker = p.paint("*", styleBold, styleRed)
+			}
+			line := fmt.Sprintf("%s %s", t.ID, t.Name)
+			if t.State != "" {
+				line += " (" + t.State + ")"
+			}
+			fmt.Fprintf(w, "  %s %s\n", marker, line)
+		}
+	}
+
+	_, err = io.WriteString(p.Out, w.String())
+	return err
+}
+
+func handledLabel(unhandled bool) string {
+	if unhandled {
+		return "unhandled"
+	}
+	return "handled"
+}
+
+// writeFrames prints a stack trace with in-project frames highlighted and
+// their code snippets shown. Runs of vendor frames are collapsed into a
+// single line, unless no frame is in the project.
+func (p *Printer) writeFrames(w io.Writer, frames []models.StackFrame) {
+	collapse := false
+	for _, f := range frames {
+		if f.InProject {
+			collapse = true
+			break
+		}
+	}
+
+	hidden := 0
+	flush := func() {
+		if hidden > 0 {
+			noun := "frames"
+			if hidden == 1 {
+				noun = "frame"
+			}
+			fmt.Fprintf(w, "    %s\n", p.paint(fmt.Sprintf("... %d vendor %s", hidden, noun), styleDim))
+			hidden = 0
+		}
+	}
+
+	for _, f := range frames {
+		if collapse && !f.InProject {
+			hidden++
+			continue
+		}
+		flush()
+
+		location := f.File
+		if f.LineNumber > 0 {
+			location += fmt.Sprintf(":%d", f.LineNumber)
+			if f.ColumnNumber > 0 {
+				location += fmt.Sprintf(":%d", f.ColumnNumber)
+			}
+		}
+		if f.InProject {
+			fmt.Fprintf(w, "  %s %s in %s\n", p.paint("→", styleYellow), p.paint(location, styleBold, styleCyan), p.paint(f.Method, styleBold))
+		} else {
+			fmt.Fprintf(w, "    %s\n", p.paint(location+" in "+f.Method, styleDim))
+		}
+
+		p.writeCode(w, f.CodeLines(), f.LineNumber)
+	}
+	flush()
+}
+
+// writeCode prints numbered source lines, marking the crash line.
+func (p *Printer) writeCode(w io.Writer, lines []models.CodeLine, crashLine int) {
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(fmt.Sprint(l.Number)))
+	}
+	for _, l := range lines {
+		num := fmt.Sprintf("%*d │ ", width, l.Number)
+		if l.Number == crashLine {
+			fmt.Fprintf(w, "      %s %s%s\n", p.paint(">", styleYellow), p.paint(num, styleYellow), p.paint(l.Text, styleBold))
+		} else {
+			fmt.Fprintf(w, "        %s%s\n", p.paint(num, styleDim), l.Text)
+		}
+	}
+}
+
+// printEventWithSource renders the event, then its in-project frames as
+// found in the local checkout, with the blamed commit when known.
+func (p *Printer) printEventWithSource(e models.EventWithSource) error {
+	if e.Event != nil {
+		if err := p.printEvent(*e.Event); err != nil {
+			return err
+		}
+	}
+
+	w := &strings.Builder{}
+	fmt.Fprintf(w, "\n%s\n", p.paint("Local source", styleBold))
+	if len(e.SourceFrames) == 0 {
+		fmt.Fprintf(w, "  %s\n", p.paint("no in-project frames", styleDim))
+	}
+	for _, f := range e.SourceFrames {
+		if f.Path == "" {
+			fmt.Fprintf(w, "  %s %s\n", p.paint("✗", styleRed), p.paint(fmt.Sprintf("%s:%d (%s)", f.File, f.LineNumber, f.Error), styleDim))
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s in %s\n", p.paint("→", styleYellow), p.paint(fmt.Sprintf("%s:%d", f.Path, f.LineNumber), styleBold, styleCyan), p.paint(f.Method, styleBold))
+		p.writeCode(w, f.Code, f.LineNumber)
+		if b := f.Blame; b != nil {
+			blame := fmt.Sprintf("%s %s", shortSHA(b.Commit), b.Author)
+			if b.Date != "" {
+				blame += ", " + b.Date
+			}
+			if b.Summary != "" {
+				blame += " · " + b.Summary
+			}
+			fmt.Fprintf(w, "      %s %s\n", p.paint("blame:", styleDim), blame)
+		}
+		if f.Error != "" {
+			fmt.Fprintf(w, "      %s\n", p.paint(f.Error, styleDim))
+		}
+	}
+
+	_, err := io.WriteString(p.Out, w.String())
+	return err
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
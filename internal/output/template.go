@@ -0,0 +1,125 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// now is the reference time for the ago template function.
+var now = time.Now
+
+// templateFuncs are available to --format template, in addition to the
+// text/template builtins. Functions taking a width or layout accept the
+// value last so that they also work in pipelines ({{.Message | truncate 40}}).
+var templateFuncs = template.FuncMap{
+	"date":     formatDate,
+	"ago":      formatAgo,
+	"truncate": truncate,
+	"padLeft":  padLeft,
+	"padRight": padRight,
+	"json":     toJSON,
+	"upper":    func(v any) string { return strings.ToUpper(fmt.Sprint(v)) },
+	"lower":    func(v any) string { return strings.ToLower(fmt.Sprint(v)) },
+}
+
+// compileTemplate parses the printer's Template, as a Go template or as a
+// JSONPath expression depending on the format.
+func (p *Printer) compileTemplate() (func(io.Writer, any) error, error) {
+	if p.Template == "" {
+		return nil, fmt.Errorf("--template is required for the %s format", p.Format)
+	}
+	if p.Format == "jsonpath" {
+		jp, err := parseJSONPath(p.Template)
+		if err != nil {
+			return nil, err
+		}
+		return jp.execute, nil
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(p.Template)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return tmpl.Execute, nil
+}
+
+func (p *Printer) executeTemplate(data any) error {
+	execute, err := p.compileTemplate()
+	if err != nil {
+		return err
+	}
+	return execute(p.Out, data)
+}
+
+func parseTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		return parsed, err == nil
+	}
+	return time.Time{}, false
+}
+
+// formatDate formats an RFC 3339 timestamp with a Go layout, returning the
+// value unchanged when it is not a timestamp.
+func formatDate(layout string, v any) string {
+	t, ok := parseTime(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	return t.Format(layout)
+}
+
+// formatAgo renders a timestamp relative to now, such as "3h ago".
+func formatAgo(v any) string {
+	t, ok := parseTime(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	d := now().Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// truncate shortens v to at most n characters, marking the cut with "...".
+func truncate(n int, v any) string {
+	s := fmt.Sprint(v)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n <= 3 {
+		return string(runes[:max(n, 0)])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+func padLeft(n int, v any) string {
+	s := fmt.Sprint(v)
+	return strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0)) + s
+}
+
+func padRight(n int, v any) string {
+	s := fmt.Sprint(v)
+	return s + strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0))
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
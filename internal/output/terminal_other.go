@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package output
+
+import "os"
+
+// terminalColumns is not supported on this platform.
+func terminalColumns(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,109 @@
+// Package source maps the file paths reported in stack frames to files in a
+// local checkout and reads code around a line.
+package source
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+// Rewrite replaces a path prefix reported by the notifier, such as the
+// directory the app was built in, with a local one.
+type Rewrite struct {
+	From string
+	To   string
+}
+
+// ParseRewrite parses a FROM=TO path prefix rewrite, such as "/app/=./".
+func ParseRewrite(s string) (Rewrite, error) {
+	from, to, ok := strings.Cut(s, "=")
+	if !ok || from == "" {
+		return Rewrite{}, fmt.Errorf("invalid path prefix %q: expected FROM=TO", s)
+	}
+	return Rewrite{From: from, To: to}, nil
+}
+
+// Locator finds frame files under Root.
+type Locator struct {
+	Root     string
+	Rewrites []Rewrite
+}
+
+// Locate returns the path, relative to Root, of the file a frame reports.
+// The first matching rewrite is applied, then the path is tried as is and
+// with its leading directories stripped one at a time, so that
+// "/build/app/src/cart.go" finds "src/cart.go" without a rewrite.
+func (l Locator) Locate(file string) (string, bool) {
+	p := l.Rewrite(file)
+
+	root, err := filepath.Abs(l.Root)
+	if err != nil {
+		return "", false
+	}
+	if filepath.IsAbs(filepath.FromSlash(p)) {
+		if rel, err := filepath.Rel(root, filepath.FromSlash(p)); err == nil && !strings.HasPrefix(rel, "..") && isFile(filepath.Join(root, rel)) {
+			return rel, true
+		}
+	}
+
+	p = strings.TrimLeft(path.Clean("/"+p), "/")
+	for p != "" {
+		if isFile(filepath.Join(root, filepath.FromSlash(p))) {
+			return filepath.FromSlash(p), true
+		}
+		_, rest, ok := strings.Cut(p, "/")
+		if !ok {
+			break
+		}
+		p = rest
+	}
+	return "", false
+}
+
+// Rewrite applies the first rewrite matching file and returns the result as
+// a slash-separated path.
+func (l Locator) Rewrite(file string) string {
+	p := filepath.ToSlash(file)
+	for _, r := range l.Rewrites {
+		if rest, ok := strings.CutPrefix(p, filepath.ToSlash(r.From)); ok {
+			return filepath.ToSlash(r.To) + rest
+		}
+	}
+	return p
+}
+
+func isFile(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Snippet returns the lines of the file within radius of line.
+func Snippet(file string, line, radius int) ([]models.CodeLine, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []models.CodeLine
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; s.Scan() && n <= line+radius; n++ {
+		if n >= line-radius {
+			lines = append(lines, models.CodeLine{Number: n, Text: strings.TrimRight(s.Text(), "\r")})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if line > 0 && (len(lines) == 0 || lines[len(lines)-1].Number < line) {
+		return nil, fmt.Errorf("%s has no line %d", file, line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yoanbernabeu/bugsnag-cli/internal/models"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRewrite(t *testing.T) {
+	r, err := ParseRewrite("/app/=./")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != (Rewrite{From: "/app/", To: "./"}) {
+		t.Errorf("unexpected rewrite %+v", r)
+	}
+	if r, err := ParseRewrite("/srv/build="); err != nil || r.To != "" {
+		t.Errorf("expected an empty target to be allowed, got %+v, %v", r, err)
+	}
+	for _, s := range []string{"/app/", "=./"} {
+		if _, err := ParseRewrite(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
+
+func TestLocator_Locate(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "src", "checkout", "cart.go"), "package checkout\n")
+	writeFile(t, filepath.Join(root, "lib", "util.go"), "package lib\n")
+
+	l := Locator{Root: root, Rewrites: []Rewrite{{From: "/app/", To: "./"}, {From: "/app/src/", To: "nowhere/"}}}
+	tests := []struct {
+		file string
+		want string
+		ok   bool
+	}{
+		{"/app/src/checkout/cart.go", "src/checkout/cart.go", true},
+		{"src/checkout/cart.go", "src/checkout/cart.go", true},
+		{"/home/ci/build/src/checkout/cart.go", "src/checkout/cart.go", true},
+		{filepath.Join(root, "lib", "util.go"), "lib/util.go", true},
+		{"github.com/acme/shop/lib/util.go", "lib/util.go", true},
+		{"/app/src/checkout", "", false},
+		{"/app/src/missing.go", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := l.Locate(tt.file)
+		if ok != tt.ok || got != filepath.FromSlash(tt.want) {
+			t.Errorf("%s: expected %q, %v, got %q, %v", tt.file, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	writeFile(t, path, "one\r\ntwo\nthree\nfour\nfive\n")
+
+	got, err := Snippet(path, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []models.CodeLine{{Number: 1, Text: "one"}, {Number: 2, Text: "two"}, {Number: 3, Text: "three"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	got, err = Snippet(path, 5, 2)
+	if err != nil || len(got) != 3 || got[2].Text != "five" {
+		t.Errorf("expected the snippet to stop at the end of the file, got %+v, %v", got, err)
+	}
+
+	if _, err := Snippet(path, 9, 1); err == nil {
+		t.Error("expected an error for a line past the end of the file")
+	}
+	if _, err := Snippet(filepath.Join(t.TempDir(), "missing.go"), 1, 1); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,87 @@
+package sourcemap
+
+import (
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Resolver finds the source map for a minified file among the .map files
+// under a directory, such as a build output folder.
+type Resolver struct {
+	// maps indexes map files by their slash-separated path relative to the
+	// root, without the .map suffix (for example "assets/app.3f9c1b.js").
+	maps  map[string]string
+	cache map[string]*Map
+}
+
+// NewResolver indexes every .map file under dir.
+func NewResolver(dir string) (*Resolver, error) {
+	r := &Resolver{maps: map[string]string{}, cache: map[string]*Map{}}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".map") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		r.maps[strings.TrimSuffix(filepath.ToSlash(rel), ".map")] = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Resolve maps a position in a minified file, given as the URL or path a
+// stack frame reports, to its original source.
+func (r *Resolver) Resolve(file string, line, column, radius int) (Position, error) {
+	mapPath := r.find(file)
+	if mapPath == "" {
+		return Position{}, ErrNoSourceMap
+	}
+	m, ok := r.cache[mapPath]
+	if !ok {
+		var err error
+		if m, err = ParseFile(mapPath); err != nil {
+			return Position{}, err
+		}
+		r.cache[mapPath] = m
+	}
+	return m.Lookup(line, column, radius)
+}
+
+// find picks the map whose relative path is the longest suffix of the
+// file's path, falling back to a map with the same base name.
+func (r *Resolver) find(file string) string {
+	p := file
+	if u, err := url.Parse(file); err == nil && u.Scheme != "" {
+		p = u.Path
+	}
+	p = strings.TrimLeft(filepath.ToSlash(p), "/")
+
+	best, bestLen := "", -1
+	for key, mapPath := range r.maps {
+		if (p == key || strings.HasSuffix(p, "/"+key)) && len(key) > bestLen {
+			best, bestLen = mapPath, len(key)
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	base := path.Base(p)
+	for key, mapPath := range r.maps {
+		if path.Base(key) == base && (best == "" || mapPath < best) {
+			best = mapPath
+		}
+	}
+	return best
+}
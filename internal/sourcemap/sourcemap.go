@@ -0,0 +1,250 @@
+// Package sourcemap resolves minified JavaScript positions to their original
+// source using Source Map v3 files read from disk. It never touches the
+// network.
+package sourcemap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Map is a decoded Source Map v3.
+type Map struct {
+	Version        int       `json:"version"`
+	File           string    `json:"file"`
+	SourceRoot     string    `json:"sourceRoot"`
+	Sources        []string  `json:"sources"`
+	SourcesContent []*string `json:"sourcesContent"`
+	Names          []string  `json:"names"`
+	Mappings       string    `json:"mappings"`
+
+	// dir is where the map was read from, to find sources on disk when
+	// sourcesContent is missing.
+	dir   string
+	lines [][]segment
+}
+
+// segment is one decoded mapping. Columns and lines are zero-based; source
+// and name are -1 when absent.
+type segment struct {
+	genColumn int
+	source    int
+	line      int
+	column    int
+	name      int
+}
+
+// Position is an original source location. Line and Column are one-based.
+type Position struct {
+	Source  string `json:"source"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Name    string `json:"name,omitempty"`
+	Context []Line `json:"context,omitempty"`
+}
+
+// Line is a line of original source.
+type Line struct {
+	Number int    `json:"line"`
+	Text   string `json:"text"`
+}
+
+var (
+	// ErrNoSourceMap is returned when no map on disk matches a file.
+	ErrNoSourceMap = errors.New("no source map found")
+	// ErrNoMapping is returned when the map has no entry for a position.
+	ErrNoMapping = errors.New("no mapping for position")
+)
+
+// Parse decodes a source map. Index maps (with sections) are not supported.
+func Parse(data []byte) (*Map, error) {
+	var m Map
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decoding source map: %w", err)
+	}
+	if m.Version != 3 {
+		return nil, fmt.Errorf("unsupported source map version %d", m.Version)
+	}
+	lines, err := decodeMappings(m.Mappings)
+	if err != nil {
+		return nil, err
+	}
+	m.lines = lines
+	return &m, nil
+}
+
+// ParseFile reads and decodes the source map at path.
+func ParseFile(path string) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	m.dir = filepath.Dir(path)
+	return m, nil
+}
+
+func decodeMappings(mappings string) ([][]segment, error) {
+	var (
+		lines                      [][]segment
+		source, line, column, name int
+	)
+	for _, group := range strings.Split(mappings, ";") {
+		var segs []segment
+		genColumn := 0
+		for _, field := range strings.Split(group, ",") {
+			if field == "" {
+				continue
+			}
+			values, err := decodeVLQ(field)
+			if err != nil {
+				return nil, err
+			}
+			seg := segment{source: -1, name: -1}
+			switch len(values) {
+			case 1, 4, 5:
+			default:
+				return nil, fmt.Errorf("invalid mapping segment %q", field)
+			}
+			genColumn += values[0]
+			seg.genColumn = genColumn
+			if len(values) >= 4 {
+				source += values[1]
+				line += values[2]
+				column += values[3]
+				seg.source, seg.line, seg.column = source, line, column
+			}
+			if len(values) == 5 {
+				name += values[4]
+				seg.name = name
+			}
+			segs = append(segs, seg)
+		}
+		sort.SliceStable(segs, func(i, j int) bool { return segs[i].genColumn < segs[j].genColumn })
+		lines = append(lines, segs)
+	}
+	return lines, nil
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// decodeVLQ decodes a run of base64 VLQ values.
+func decodeVLQ(s string) ([]int, error) {
+	var values []int
+	value, shift := 0, 0
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base64Chars, s[i])
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base64 VLQ character %q", s[i])
+		}
+		value += (digit & 31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 {
+		return nil, fmt.Errorf("truncated base64 VLQ value in %q", s)
+	}
+	return values, nil
+}
+
+// Lookup maps a one-based generated line and column, as reported in stack
+// traces, to the original position, with radius lines of context on either
+// side when the source is available.
+func (m *Map) Lookup(line, column, radius int) (Position, error) {
+	if line < 1 || line > len(m.lines) {
+		return Position{}, ErrNoMapping
+	}
+	segs := m.lines[line-1]
+	col := max(column-1, 0)
+	i := sort.Search(len(segs), func(i int) bool { return segs[i].genColumn > col }) - 1
+	if i < 0 || segs[i].source < 0 || segs[i].source >= len(m.Sources) {
+		return Position{}, ErrNoMapping
+	}
+	seg := segs[i]
+
+	pos := Position{
+		Source: m.sourcePath(seg.source),
+		Line:   seg.line + 1,
+		Column: seg.column + 1,
+	}
+	if seg.name >= 0 && seg.name < len(m.Names) {
+		pos.Name = m.Names[seg.name]
+	}
+	if radius >= 0 {
+		pos.Context = m.context(seg.source, pos.Line, radius)
+	}
+	return pos, nil
+}
+
+func (m *Map) sourcePath(i int) string {
+	src := m.Sources[i]
+	if m.SourceRoot != "" && !strings.Contains(src, "://") && !path.IsAbs(src) {
+		return strings.TrimSuffix(m.SourceRoot, "/") + "/" + src
+	}
+	return src
+}
+
+// context returns the lines around line from sourcesContent, or from the
+// source file next to the map when the content is not embedded.
+func (m *Map) context(source, line, radius int) []Line {
+	var content string
+	if source < len(m.SourcesContent) && m.SourcesContent[source] != nil {
+		content = *m.SourcesContent[source]
+	} else if file, ok := m.localFile(source); ok {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil
+		}
+		content = string(data)
+	} else {
+		return nil
+	}
+
+	all := strings.Split(content, "\n")
+	var out []Line
+	for n := max(line-radius, 1); n <= min(line+radius, len(all)); n++ {
+		out = append(out, Line{Number: n, Text: strings.TrimRight(all[n-1], "\r")})
+	}
+	return out
+}
+
+// localFile returns where source is on disk, relative to the map, or false
+// if the map was not read from disk or source would resolve outside its
+// directory.
+func (m *Map) localFile(source int) (string, bool) {
+	if m.dir == "" {
+		return "", false
+	}
+	file := filepath.Join(m.dir, filepath.FromSlash(localPath(m.sourcePath(source))))
+	rel, err := filepath.Rel(m.dir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return file, true
+}
+
+// localPath strips bundler URL schemes such as webpack:/// so that a source
+// can be looked up relative to the map.
+func localPath(src string) string {
+	if _, rest, ok := strings.Cut(src, "://"); ok {
+		src = rest
+	}
+	return strings.TrimPrefix(strings.TrimLeft(src, "/"), "./")
+}
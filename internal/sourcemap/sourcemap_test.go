@@ -0,0 +1,225 @@
+package sourcemap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []int
+	}{
+		{"A", []int{0}},
+		{"C", []int{1}},
+		{"D", []int{-1}},
+		{"gB", []int{16}},
+		{"2H", []int{123}},
+		{"AAAA", []int{0, 0, 0, 0}},
+		{"wk+CAWIC", []int{48200, 0, 11, 4, 1}},
+		{"oGAQJ", []int{100, 0, 8, -4}},
+	}
+	for _, tt := range tests {
+		got, err := decodeVLQ(tt.in)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.in, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: expected %v, got %v", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestDecodeVLQ_Errors(t *testing.T) {
+	for _, in := range []string{"g", "A!", "wk+"} {
+		if _, err := decodeVLQ(in); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"not json":    `{`,
+		"version 2":   `{"version":2,"mappings":""}`,
+		"bad segment": `{"version":3,"sources":["a.js"],"mappings":"AA"}`,
+		"bad base64":  `{"version":3,"sources":["a.js"],"mappings":"A*"}`,
+	}
+	for name, data := range tests {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	m, err := ParseFile(filepath.Join("testdata", "dist", "assets", "app.3f9c1b.js.map"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pos, err := m.Lookup(1, 48213, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Position{
+		Source: "webpack:///./src/checkout/Summary.jsx",
+		Line:   12,
+		Column: 5,
+		Name:   "total",
+		Context: []Line{
+			{Number: 11, Text: "  return formatPrice("},
+			{Number: 12, Text: "    totals.total,"},
+			{Number: 13, Text: "    cart.currency,"},
+		},
+	}
+	if !reflect.DeepEqual(pos, want) {
+		t.Errorf("expected %+v, got %+v", want, pos)
+	}
+
+	// A column before the second segment resolves to the first one, which
+	// has no name.
+	pos, err = m.Lookup(1, 10, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.Line != 1 || pos.Column != 1 || pos.Name != "" || pos.Context != nil {
+		t.Errorf("unexpected position %+v", pos)
+	}
+
+	// Exactly on the last segment.
+	pos, err = m.Lookup(1, 48301, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.Line != 20 || pos.Column != 1 || len(pos.Context) != 1 || pos.Context[0].Text != "" {
+		t.Errorf("unexpected position %+v", pos)
+	}
+
+	for _, line := range []int{0, 2, 3} {
+		if _, err := m.Lookup(line, 1, 0); !errors.Is(err, ErrNoMapping) {
+			t.Errorf("line %d: expected ErrNoMapping, got %v", line, err)
+		}
+	}
+}
+
+func TestLookup_SourceFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.js"), []byte("one\r\ntwo\r\nthree\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Second generated line maps to the second original line, column 3.
+	mapData := `{"version":3,"sourceRoot":"src","sources":["main.js"],"names":[],"mappings":";AACE"}`
+	if err := os.WriteFile(filepath.Join(dir, "main.min.js.map"), []byte(mapData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ParseFile(filepath.Join(dir, "main.min.js.map"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pos, err := m.Lookup(2, 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Position{
+		Source:  "src/main.js",
+		Line:    2,
+		Column:  3,
+		Context: []Line{{1, "one"}, {2, "two"}, {3, "three"}},
+	}
+	if !reflect.DeepEqual(pos, want) {
+		t.Errorf("expected %+v, got %+v", want, pos)
+	}
+}
+
+func TestLookup_SourceOutsideMapDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "secret.txt"), []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "dist")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, src := range []string{"../secret.txt", "webpack:///../secret.txt", "src/../../secret.txt"} {
+		mapData := `{"version":3,"sources":["` + src + `"],"names":[],"mappings":";AACE"}`
+		if err := os.WriteFile(filepath.Join(dir, "main.min.js.map"), []byte(mapData), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		m, err := ParseFile(filepath.Join(dir, "main.min.js.map"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pos, err := m.Lookup(2, 1, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pos.Context != nil {
+			t.Errorf("%s: expected no context from outside the map directory, got %+v", src, pos.Context)
+		}
+	}
+}
+
+func TestResolver(t *testing.T) {
+	r, err := NewResolver(filepath.Join("testdata", "dist"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, file := range []string{
+		"https://shop.acme.test/assets/app.3f9c1b.js",
+		"https://shop.acme.test/assets/app.3f9c1b.js?v=2",
+		"/var/www/assets/app.3f9c1b.js",
+		"https://cdn.acme.test/static/app.3f9c1b.js",
+	} {
+		pos, err := r.Resolve(file, 1, 48213, 0)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", file, err)
+			continue
+		}
+		if pos.Line != 12 || pos.Name != "total" {
+			t.Errorf("%s: unexpected position %+v", file, pos)
+		}
+	}
+
+	if _, err := r.Resolve("https://shop.acme.test/assets/vendor.8d1e2a.js", 2, 11876, 0); !errors.Is(err, ErrNoSourceMap) {
+		t.Errorf("expected ErrNoSourceMap, got %v", err)
+	}
+}
+
+func TestResolver_PrefersLongestPathMatch(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{"main.js.map", filepath.Join("admin", "main.js.map")} {
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(`{"version":3,"sources":[],"mappings":""}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewResolver(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := r.find("https://acme.test/admin/main.js"), filepath.Join(dir, "admin", "main.js.map"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got, want := r.find("https://acme.test/main.js"), filepath.Join(dir, "main.js.map"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestNewResolver_MissingDir(t *testing.T) {
+	if _, err := NewResolver(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}